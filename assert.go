@@ -1,47 +1,265 @@
 package assert
 
 import (
+	"fmt"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/go-repo/assert/internal"
 )
 
-func Equal(t *testing.T, actual, expected interface{}) {
+func Equal(t *testing.T, actual, expected interface{}, opts ...Option) {
 	t.Helper()
 
-	if !internal.Equal(t, actual, expected) {
-		t.FailNow()
-	}
+	New(t).Equal(actual, expected, opts...)
 }
 
-func NotEqual(t *testing.T, actual, expected interface{}) {
+func NotEqual(t *testing.T, actual, expected interface{}, opts ...Option) {
 	t.Helper()
 
-	if !internal.NotEqual(t, actual, expected) {
-		t.FailNow()
-	}
+	New(t).NotEqual(actual, expected, opts...)
 }
 
-func NoError(t *testing.T, err error) {
+func NoError(t *testing.T, err error, opts ...Option) {
 	t.Helper()
 
-	if !internal.NoError(t, err) {
-		t.FailNow()
-	}
+	New(t).NoError(err, opts...)
 }
 
-func Nil(t *testing.T, actual interface{}) {
+func Nil(t *testing.T, actual interface{}, opts ...Option) {
 	t.Helper()
 
-	if !internal.Nil(t, actual) {
-		t.FailNow()
-	}
+	New(t).Nil(actual, opts...)
+}
+
+func NotNil(t *testing.T, actual interface{}, opts ...Option) {
+	t.Helper()
+
+	New(t).NotNil(actual, opts...)
+}
+
+func True(t *testing.T, value bool, opts ...Option) {
+	t.Helper()
+
+	New(t).True(value, opts...)
+}
+
+func False(t *testing.T, value bool, opts ...Option) {
+	t.Helper()
+
+	New(t).False(value, opts...)
+}
+
+func Condition(t *testing.T, condition func() bool, opts ...Option) {
+	t.Helper()
+
+	New(t).Condition(condition, opts...)
+}
+
+func InDelta(t *testing.T, actual, expected interface{}, delta float64, opts ...Option) {
+	t.Helper()
+
+	New(t).InDelta(actual, expected, delta, opts...)
+}
+
+func InEpsilon(t *testing.T, actual, expected interface{}, epsilon float64, opts ...Option) {
+	t.Helper()
+
+	New(t).InEpsilon(actual, expected, epsilon, opts...)
+}
+
+func WithinULP(t *testing.T, actual, expected interface{}, ulps uint64, opts ...Option) {
+	t.Helper()
+
+	New(t).WithinULP(actual, expected, ulps, opts...)
+}
+
+func WithinDuration(t *testing.T, actual, expected time.Time, delta time.Duration, opts ...Option) {
+	t.Helper()
+
+	New(t).WithinDuration(actual, expected, delta, opts...)
+}
+
+func TimeEqual(t *testing.T, actual, expected time.Time, opts ...Option) {
+	t.Helper()
+
+	New(t).TimeEqual(actual, expected, opts...)
+}
+
+func Greater(t *testing.T, actual, expected interface{}, opts ...Option) {
+	t.Helper()
+
+	New(t).Greater(actual, expected, opts...)
+}
+
+func GreaterOrEqual(t *testing.T, actual, expected interface{}, opts ...Option) {
+	t.Helper()
+
+	New(t).GreaterOrEqual(actual, expected, opts...)
+}
+
+func Less(t *testing.T, actual, expected interface{}, opts ...Option) {
+	t.Helper()
+
+	New(t).Less(actual, expected, opts...)
+}
+
+func LessOrEqual(t *testing.T, actual, expected interface{}, opts ...Option) {
+	t.Helper()
+
+	New(t).LessOrEqual(actual, expected, opts...)
+}
+
+func Between(t *testing.T, actual, min, max interface{}, opts ...Option) {
+	t.Helper()
+
+	New(t).Between(actual, min, max, opts...)
+}
+
+func IsSorted(t *testing.T, slice interface{}, opts ...Option) {
+	t.Helper()
+
+	New(t).IsSorted(slice, opts...)
 }
 
-func NotNil(t *testing.T, actual interface{}) {
+func IsSortedBy(t *testing.T, slice interface{}, less func(i, j int) bool, opts ...Option) {
 	t.Helper()
 
-	if !internal.NotNil(t, actual) {
-		t.FailNow()
+	New(t).IsSortedBy(slice, less, opts...)
+}
+
+func IsStrictlyIncreasing(t *testing.T, slice interface{}, opts ...Option) {
+	t.Helper()
+
+	New(t).IsStrictlyIncreasing(slice, opts...)
+}
+
+func IsType(t *testing.T, expectedType, actual interface{}, opts ...Option) {
+	t.Helper()
+
+	New(t).IsType(expectedType, actual, opts...)
+}
+
+func Implements(t *testing.T, interfaceObject, actual interface{}, opts ...Option) {
+	t.Helper()
+
+	New(t).Implements(interfaceObject, actual, opts...)
+}
+
+func AssignableTo(t *testing.T, expectedType, actual interface{}, opts ...Option) {
+	t.Helper()
+
+	New(t).AssignableTo(expectedType, actual, opts...)
+}
+
+func ConvertibleTo(t *testing.T, expectedType, actual interface{}, opts ...Option) {
+	t.Helper()
+
+	New(t).ConvertibleTo(expectedType, actual, opts...)
+}
+
+// As returns actual as type T, if it has another type, the test fails and
+// stops.
+func As[T any](t *testing.T, actual interface{}, opts ...Option) T {
+	t.Helper()
+
+	v, _ := AsOf[T](New(t), actual, opts...)
+	return v
+}
+
+// JSONEq decodes actual and expected JSON, which can be a string, []byte,
+// json.RawMessage or io.Reader, and checks they are semantically equal.
+func JSONEq(t *testing.T, actual, expected interface{}, opts ...Option) {
+	t.Helper()
+
+	New(t).JSONEq(actual, expected, opts...)
+}
+
+// Snapshot compares actual with the inline snapshot expected, bytes and
+// strings are compared as is, other values as formatted by diff.Sprint.
+// With UPDATE_SNAPSHOTS=1, or the -golden.update flag of the golden
+// package, expected is rewritten in the source file of the caller, so it
+// must be a string literal.
+func Snapshot(t *testing.T, actual interface{}, expected string, opts ...Option) {
+	t.Helper()
+
+	New(t).Snapshot(actual, expected, opts...)
+}
+
+// Receives receives a value from ch within a second and compares it to
+// expected like Equal.
+func Receives(t *testing.T, ch, expected interface{}, opts ...Option) {
+	t.Helper()
+
+	New(t).Receives(ch, expected, opts...)
+}
+
+func ReceivesWithin(t *testing.T, ch, expected interface{}, timeout time.Duration, opts ...Option) {
+	t.Helper()
+
+	New(t).ReceivesWithin(ch, expected, timeout, opts...)
+}
+
+// NeverReceives fails if ch has a value or is closed within the window.
+func NeverReceives(t *testing.T, ch interface{}, window time.Duration, opts ...Option) {
+	t.Helper()
+
+	New(t).NeverReceives(ch, window, opts...)
+}
+
+// Closed waits up to a second for ch to be closed and fails if it had
+// values before.
+func Closed(t *testing.T, ch interface{}, opts ...Option) {
+	t.Helper()
+
+	New(t).Closed(ch, opts...)
+}
+
+// ReceivesSequence receives as many values as the slice expected has
+// within timeout and compares them to expected like Equal.
+func ReceivesSequence(t *testing.T, ch, expected interface{}, timeout time.Duration, opts ...Option) {
+	t.Helper()
+
+	New(t).ReceivesSequence(ch, expected, timeout, opts...)
+}
+
+// ReceivesUnordered is like ReceivesSequence but the values can be
+// received in any order.
+func ReceivesUnordered(t *testing.T, ch, expected interface{}, timeout time.Duration, opts ...Option) {
+	t.Helper()
+
+	New(t).ReceivesUnordered(ch, expected, timeout, opts...)
+}
+
+// NoGoroutineLeaks fails when the test ends if goroutines started by the
+// test goroutine, or by any goroutine since the call, didn't exit within a
+// second. Cleanups registered later run before the check. It can be called
+// at the start of the test or deferred:
+//
+//	defer assert.NoGoroutineLeaks(t)
+func NoGoroutineLeaks(t *testing.T, opts ...Option) {
+	t.Helper()
+
+	New(t).NoGoroutineLeaks(opts...)
+}
+
+// NoGoroutineLeaksMain runs the tests and fails if goroutines started by
+// them didn't exit, it's called by TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(assert.NoGoroutineLeaksMain(m))
+//	}
+func NoGoroutineLeaksMain(m *testing.M, opts ...Option) int {
+	snapshot := internal.Goroutines()
+	code := m.Run()
+	if code != 0 {
+		return code
+	}
+
+	if msg := internal.GoroutineLeaks(snapshot, 0, opts...); msg != "" {
+		fmt.Fprintln(os.Stderr, msg)
+		return 1
 	}
+	return 0
 }
@@ -1,14 +1,21 @@
 package assert_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"os/exec"
 	"reflect"
 	"runtime"
+	"runtime/debug"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-repo/assert"
 	"github.com/go-repo/assert/errorassert"
@@ -62,6 +69,24 @@ var tests = []struct {
 		expectedIsExitError: true,
 	},
 
+	{
+		fn:                  testEqual_Matcher_Expected,
+		expectedOutput:      "",
+		expectedIsExitError: false,
+	},
+
+	{
+		fn: testEqual_Matcher_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Actual (-) and expected (+) are not equal:
+              []interface {}{
+            -     0: int(1)
+            +     0: AnyString() // got int
+            -     1: string("bob")
+            +     1: Regexp("^a") // no match
+              }`,
+		expectedIsExitError: true,
+	},
+
 	{
 		fn: testErrorAssert_Equal_Unexpected,
 		expectedOutput: `        assert_test.go:%v: Actual (-) and expected (+) are not equal:
@@ -74,6 +99,375 @@ var tests = []struct {
 		expectedIsExitError: true,
 	},
 
+	{
+		fn:                  testEqual_FloatTolerance_Expected,
+		expectedOutput:      "",
+		expectedIsExitError: false,
+	},
+
+	{
+		fn: testEqual_FloatTolerance_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Actual (-) and expected (+) are not equal:
+              []float64{
+            -     1: float64(2)
+            +     1: float64(2.5) // delta 0.5 > 0.1
+              }`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn:                  testInDelta_Expected,
+		expectedOutput:      "",
+		expectedIsExitError: false,
+	},
+
+	{
+		fn: testInDelta_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Actual (-) and expected (+) are not within delta 0.1:
+            - float64(3)
+            + float64(3.5) // delta 0.5 > 0.1`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn: testErrorAssert_InEpsilon_WithinULP_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Actual (-) and expected (+) are not within epsilon 0.01:
+            - float64(100)
+            + float64(110) // relative 0.09090909090909091 > 0.01
+            
+        assert_test.go:%v: Actual (-) and expected (+) are not within ulps 1:
+            - float32(1)
+            + float32(1.0000002) // ulp 2 > 1
+            
+        assert_test.go:%v: Actual (-) and expected (+) are not within ulps 0:
+            - float64(1)
+            + float64(1.0000000000000002) // ulp 1 > 0`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn:                  testWithinDuration_Expected,
+		expectedOutput:      "",
+		expectedIsExitError: false,
+	},
+
+	{
+		fn: testWithinDuration_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Actual (-) and expected (+) are not within 1s:
+            - time.Time(2020-01-01T00:00:00Z)
+            + time.Time(2020-01-01T00:00:02Z) // skew -2s > 1s`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn: testErrorAssert_TimeEqual_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Actual (-) and expected (+) are not the same instant:
+            - time.Time(2020-01-01T00:00:00Z)
+            + time.Time(2020-01-01T00:00:00.5Z) // skew -500ms`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn:                  testOrder_Expected,
+		expectedOutput:      "",
+		expectedIsExitError: false,
+	},
+
+	{
+		fn: testErrorAssert_Order_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Expected greater than 2 but got: 1
+        assert_test.go:%v: Expected less than or equal to "a" but got: "b"
+        assert_test.go:%v: Expected between 2020-01-01T00:00:00Z and 2020-01-02T00:00:00Z but got: 2020-01-03T00:00:00Z
+        assert_test.go:%v: Can't compare 1 and "1"`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn: testIsSorted_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Expected sorted, elements 4 and 5 are out of order:
+              []int{
+                  ...
+                  2: int(3)
+                  3: int(4)
+            >     4: int(6)
+            >     5: int(5)
+                  6: int(7)
+                  7: int(8)
+                  ...
+              }`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn: testErrorAssert_IsStrictlyIncreasing_IsSortedBy_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Expected strictly increasing, elements 0 and 1 are out of order:
+              []string{
+            >     0: string("a")
+            >     1: string("a")
+              }
+            
+        assert_test.go:%v: Expected sorted, elements 0 and 1 are out of order:
+              []assert_test.testStruct{
+            >     0: assert_test.testStruct{Field1:"c"}
+            >     1: assert_test.testStruct{Field1:"b"}
+                  2: assert_test.testStruct{Field1:"a"}
+              }`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn:                  testTypes_Expected,
+		expectedOutput:      "",
+		expectedIsExitError: false,
+	},
+
+	{
+		fn: testErrorAssert_Types_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Expected type *assert_test.testStruct but got assert_test.testStruct: assert_test.testStruct{Field1:""}
+        assert_test.go:%v: Expected implementation of io.Reader but got int: 1
+        assert_test.go:%v: Expected value assignable to string but got int: 1
+        assert_test.go:%v: Expected value convertible to int but got string: "1"
+        assert_test.go:%v: Expected type error but got nil: <nil>
+        assert_test.go:%v: <nil>`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn:                  testAs_Unexpected,
+		expectedOutput:      `        assert_test.go:%v: Expected type *strings.Reader but got *bytes.Buffer: &bytes.Buffer{`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn:                  testJSONEq_Expected,
+		expectedOutput:      "",
+		expectedIsExitError: false,
+	},
+
+	{
+		fn: testJSONEq_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Actual (-) and expected (+) JSON are not equal:
+            - $.items[1].price: number(3.5)
+            + $.items[1].price: string("3.5")
+            - $.items[2]: object({"id":3})
+            - $["the name"]: null
+            + $["the name"]: array([1])
+            + $.total: number(10)`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn: testErrorAssert_JSONEq_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Can't decode actual JSON: unexpected end of JSON input
+        assert_test.go:%v: Actual (-) and expected (+) JSON are not equal:
+            - $: boolean(true)
+            + $: number(1)
+            
+        assert_test.go:%v: Actual (-) and expected (+) JSON are not equal:
+            - $.id: number(12345678901234567891)
+            + $.id: number(12345678901234567892)
+            - $.price: number(1.100000000000000000001)
+            + $.price: number(1.1)`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn:                  testSnapshot_Expected,
+		expectedOutput:      "",
+		expectedIsExitError: false,
+	},
+
+	{
+		fn: testErrorAssert_Snapshot_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Actual (-) and snapshot (+) are not equal:
+              []string{
+            -     1: string("      Field1: string(\"a\")")
+            +     1: string("      Field1: string(\"b\")")
+              }
+            
+        assert_test.go:%v: Actual (-) and snapshot (+) are not equal:
+              []string{
+            -     0: string("abc")
+            +     0: string("abd")
+              }`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn: testEqual_GoSyntax_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Actual (-) and expected (+) are not equal:
+              []assert_test.testStruct{
+            -     0: assert_test.testStruct({a})
+              }
+            
+            Actual in Go syntax:
+            []assert_test.testStruct{
+            	assert_test.testStruct{
+            		Field1: "a",
+            	},
+            }`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn: testEqual_SideBySide_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Actual (-) and expected (+) are not equal:
+            []assert_test.testStruct{           []assert_test.testStruct{
+                0: assert_test.testStruct{          0: assert_test.testStruct{
+                    Field1: string("a")       |         Field1: string("b")
+                }                                   }
+            }                                   }
+            
+        assert_test.go:%v: Actual (-) and expected (+) are not equal:
+            map[string]int{      map[string]int{
+                "a": int(1)    |     "a": int(2)
+            }                    }`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn: testErrorAssert_Msg_With_Unexpected,
+		expectedOutput: `        assert_test.go:%v: user 1: Expected nil but got: 1
+        assert_test.go:%v: user=2, order=<missing>: totals: Actual (-) and expected (+) are not equal:
+            - int(1)
+            + int(2)
+            
+        assert_test.go:%v: user=3, order=<missing>: Expected sorted, elements 0 and 1 are out of order:
+              []int{
+            >     0: int(2)
+            >     1: int(1)
+              }
+            
+        --- FAIL: TestRun/github.com/go-repo/assert_test.testErrorAssert_Msg_With_Unexpected/sub (0.00s)
+            assert_test.go:%v: user=3: Got unexpected error: err`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn: testAssertions_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Actual (-) and expected (+) are not equal:
+            - int(1)
+            + int(2)
+            
+        assert_test.go:%v: log only false false
+        assert_test.go:%v: Expected nil but got: 1
+        assert_test.go:%v: custom true false
+        assert_test.go:%v: Expected greater than 2 but got: 1
+        assert_test.go:%v: Expected not nil but got nil: <nil>
+FAIL`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn: testSoft_Unexpected,
+		expectedOutput: `        assert_test.go:%v: after soft true
+        assert_test.go:%v: 2 soft assertions failed:
+            1) assert_test.go:%v: Actual (-) and expected (+) are not equal:
+               - int(1)
+               + int(2)
+            2) assert_test.go:%v: n=3: Expected nil but got: 1
+        assert_test.go:%v: soft false true
+        assert_test.go:%v: 1 soft assertion failed:
+            1) assert_test.go:%v: n=3: Expected greater than 2 but got: 1
+FAIL`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn: testGo_Unexpected,
+		expectedOutput: `        assert_test.go:%v: failed false
+        assert_test.go:%v: assert_test.go:%v: Actual (-) and expected (+) are not equal:
+            - int(1)
+            + int(2)
+            Goroutine stack:
+            github.com/go-repo/assert_test.testGo_Unexpected.func1()
+            	%v:%v
+        assert_test.go:%v: go false true
+        assert_test.go:%v: assert_test.go:%v: Expected nil but got: 1
+            Goroutine stack:
+            github.com/go-repo/assert_test.testGo_Unexpected.func2()
+            	%v:%v
+FAIL`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn: testEqual_Expressions_Unexpected,
+		expectedOutput: `        assert_test.go:%v: actual:   items[0].Price
+            expected: 2
+            Actual (-) and expected (+) are not equal:
+            - int(1)
+            + int(2)
+            
+        assert_test.go:%v: total: actual:   len(items)
+            expected: 0
+            Actual (-) and expected (+) are not equal:
+            - int(1)
+            + int(0)`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn: testTrue_False_Condition_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Expected true: len(users) > 1 && users[0].Active
+              len(users) = 1
+              users = []struct { Active bool }{struct { Active bool }{Active:false}}
+              users[0].Active = false
+              users[0] = struct { Active bool }{Active:false}
+        assert_test.go:%v: Expected false: ok
+        assert_test.go:%v: Expected condition to be true: len(users) == 0
+              len(users) = 1`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn:                  testTrue_False_Condition_Expected,
+		expectedOutput:      "",
+		expectedIsExitError: false,
+	},
+
+	{
+		fn: testNoGoroutineLeaks_Unexpected,
+		expectedOutput: `            assert_test.go:%v: Found 1 leaked goroutine:
+                goroutine %v [chan receive] created by github.com/go-repo/assert_test.testNoGoroutineLeaks_Unexpected.func2 at %v:%v:
+                  github.com/go-repo/assert_test.testNoGoroutineLeaks_Unexpected.func2.1()
+                  	%v:%v
+FAIL`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn: testChannels_Unexpected,
+		expectedOutput: `        assert_test.go:%v: Received (-) and expected (+) are not equal:
+            - int(1)
+            + int(2)
+            
+        assert_test.go:%v: Received nothing within 10ms, expected: 3
+        assert_test.go:%v: Expected no value within 10ms but received: 4
+        assert_test.go:%v: Expected drained channel but received: []int{5}
+        assert_test.go:%v: Received (-) and expected (+) sequences are not equal, channel is closed:
+              []int{
+            -     1: int(3)
+            +     1: int(2)
+            +     2: int(3)
+              }
+            
+        assert_test.go:%v: Received (-) and expected (+) sequences are not equal:
+              []int{
+            -     2: int(4)
+            +     2: int(5)
+              }
+            
+        assert_test.go:%v: Expected receive channel but got: 1`,
+		expectedIsExitError: true,
+	},
+
+	{
+		fn:                  testChannels_Expected,
+		expectedOutput:      "",
+		expectedIsExitError: false,
+	},
+
 	{
 		fn:                  testNotEqual_Expected,
 		expectedOutput:      "",
@@ -187,6 +581,31 @@ func testEqual_Unexpected_NilAndEmptySlice(t *testing.T) {
 	assert.Equal(t, []byte(nil), []byte{})
 }
 
+func testEqual_Matcher_Expected(t *testing.T) {
+	assert.Equal(t,
+		[]interface{}{"id", 3, 2.5, time.Now(), nil, 1, "abc"},
+		[]interface{}{
+			assert.AnyString(),
+			assert.InRange(1, 5),
+			assert.Approx(2.4, 0.2),
+			assert.AnyTime(),
+			assert.Any(),
+			assert.AnyOf(0, 1),
+			assert.Regexp("^a"),
+		},
+	)
+	assert.Equal(t, 1, assert.NotZero())
+}
+
+func testEqual_Matcher_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Println(line + 2)
+	assert.Equal(t,
+		[]interface{}{1, "bob"},
+		[]interface{}{assert.AnyString(), assert.Regexp("^a")},
+	)
+}
+
 func testErrorAssert_Equal_Unexpected(t *testing.T) {
 	_, _, line, _ := runtime.Caller(0)
 	fmt.Printf("%v:%v\n", line+2, line+3)
@@ -194,6 +613,352 @@ func testErrorAssert_Equal_Unexpected(t *testing.T) {
 	errorassert.Equal(t, "78", "90")
 }
 
+func testEqual_FloatTolerance_Expected(t *testing.T) {
+	assert.Equal(t,
+		map[string]float64{"a": 1, "b": math.NaN()},
+		map[string]float64{"a": 1.05, "b": math.NaN()},
+		assert.FloatDelta(0.1), assert.EquateNaNs(),
+	)
+}
+
+func testEqual_FloatTolerance_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Println(line + 2)
+	assert.Equal(t, []float64{1, 2}, []float64{1.05, 2.5}, assert.FloatDelta(0.1))
+}
+
+func testInDelta_Expected(t *testing.T) {
+	assert.InDelta(t, 3, 3.05, 0.1)
+	assert.InEpsilon(t, 100.0, 100.5, 0.01)
+	assert.WithinULP(t, 1.0, math.Nextafter(1, 2), 1)
+	assert.InDelta(t, math.NaN(), math.NaN(), 0.1, assert.EquateNaNs())
+}
+
+func testInDelta_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Println(line + 2)
+	assert.InDelta(t, 3, 3.5, 0.1)
+}
+
+func testErrorAssert_InEpsilon_WithinULP_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Printf("%v:%v:%v\n", line+2, line+3, line+4)
+	errorassert.InEpsilon(t, 100, 110, 0.01)
+	errorassert.WithinULP(t, float32(1), math.Nextafter32(math.Nextafter32(1, 2), 2), 1)
+	errorassert.WithinULP(t, 1.0, math.Nextafter(1, 2), 0)
+}
+
+func testWithinDuration_Expected(t *testing.T) {
+	now := time.Now()
+	assert.WithinDuration(t, now, now.Add(time.Second), time.Second)
+	assert.TimeEqual(t, now, now.Round(0).In(time.FixedZone("", 3600)))
+	assert.Equal(t,
+		struct{ CreatedAt time.Time }{now},
+		struct{ CreatedAt time.Time }{now.Truncate(time.Millisecond)},
+		assert.TimeTolerance(time.Millisecond),
+	)
+}
+
+func testWithinDuration_Unexpected(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Println(line + 2)
+	assert.WithinDuration(t, tm, tm.Add(2*time.Second), time.Second)
+}
+
+func testErrorAssert_TimeEqual_Unexpected(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Println(line + 2)
+	errorassert.TimeEqual(t, tm, tm.Add(500*time.Millisecond))
+}
+
+func testOrder_Expected(t *testing.T) {
+	now := time.Now()
+	assert.Greater(t, 2, 1.5)
+	assert.GreaterOrEqual(t, uint8(2), -1)
+	assert.Less(t, "a", "b")
+	assert.LessOrEqual(t, now, now)
+	assert.Between(t, 3, 3, 5)
+	assert.IsSorted(t, []int{1, 1, 2})
+	assert.IsSorted(t, [0]int{})
+	assert.IsStrictlyIncreasing(t, []time.Time{now, now.Add(1)})
+
+	s := []testStruct{{Field1: "c"}, {Field1: "b"}}
+	assert.IsSortedBy(t, s, func(i, j int) bool { return s[i].Field1 > s[j].Field1 })
+}
+
+func testErrorAssert_Order_Unexpected(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Printf("%v:%v:%v:%v\n", line+2, line+3, line+4, line+5)
+	errorassert.Greater(t, 1, 2)
+	errorassert.LessOrEqual(t, "b", "a")
+	errorassert.Between(t, tm.AddDate(0, 0, 2), tm, tm.AddDate(0, 0, 1))
+	errorassert.Less(t, 1, "1")
+}
+
+func testIsSorted_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Println(line + 2)
+	assert.IsSorted(t, []int{1, 2, 3, 4, 6, 5, 7, 8, 9})
+}
+
+func testErrorAssert_IsStrictlyIncreasing_IsSortedBy_Unexpected(t *testing.T) {
+	s := []testStruct{{Field1: "c"}, {Field1: "b"}, {Field1: "a"}}
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Printf("%v:%v\n", line+2, line+3)
+	errorassert.IsStrictlyIncreasing(t, []string{"a", "a"})
+	errorassert.IsSortedBy(t, s, func(i, j int) bool { return s[i].Field1 < s[j].Field1 })
+}
+
+func testTypes_Expected(t *testing.T) {
+	assert.IsType(t, &testStruct{}, &testStruct{Field1: "a"})
+	assert.Implements(t, (*io.Reader)(nil), &bytes.Buffer{})
+	assert.AssignableTo(t, sort.IntSlice(nil), []int{1})
+	assert.ConvertibleTo(t, 1.5, 1)
+	assert.AssignableTo(t, (*error)(nil), os.ErrNotExist)
+	assert.ConvertibleTo(t, (*io.Reader)(nil), &bytes.Buffer{})
+
+	r := assert.As[io.Reader](t, strings.NewReader("abc"))
+	assert.Equal(t, r.(*strings.Reader).Len(), 3)
+}
+
+func testErrorAssert_Types_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Printf("%v:%v:%v:%v:%v:%v\n", line+2, line+3, line+4, line+5, line+6, line+7)
+	errorassert.IsType(t, &testStruct{}, testStruct{})
+	errorassert.Implements(t, (*io.Reader)(nil), 1)
+	errorassert.AssignableTo(t, "", 1)
+	errorassert.ConvertibleTo(t, 1, "1")
+	err := errorassert.As[error](t, nil)
+	t.Log(err)
+}
+
+func testAs_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Println(line + 2)
+	r := assert.As[*strings.Reader](t, &bytes.Buffer{})
+	t.Log(r.Len())
+}
+
+func testJSONEq_Expected(t *testing.T) {
+	assert.JSONEq(t, `{"a": 1, "b": [true, null]}`, []byte(`{"b":[true,null],"a":1.0}`))
+	assert.JSONEq(t, strings.NewReader(`"s"`), json.RawMessage(`"s"`))
+	assert.JSONEq(t, `{"price": 1.01}`, `{"price": 1}`, assert.FloatDelta(0.1))
+	assert.JSONEq(t, `[12345678901234567891, 0.10, 1.100000000000000000001]`, `[1.2345678901234567891e19, 0.1, 1.1000000000000000000010]`)
+}
+
+func testJSONEq_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Println(line + 2)
+	assert.JSONEq(t,
+		`{"items": [{"id": 1}, {"id": 2, "price": 3.5}, {"id": 3}], "the name": null}`,
+		`{"items": [{"id": 1}, {"id": 2, "price": "3.5"}], "the name": [1], "total": 10}`,
+	)
+}
+
+func testErrorAssert_JSONEq_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Printf("%v:%v:%v\n", line+2, line+3, line+4)
+	errorassert.JSONEq(t, `{`, `{}`)
+	errorassert.JSONEq(t, `true`, `1`)
+	errorassert.JSONEq(t, `{"id": 12345678901234567891, "price": 1.100000000000000000001}`, `{"id": 12345678901234567892, "price": 1.1}`)
+}
+
+func testSnapshot_Expected(t *testing.T) {
+	assert.Snapshot(t, "abc", "abc")
+	assert.Snapshot(t, &testStruct{Field1: "a"}, `  &assert_test.testStruct{
+      Field1: string("a")
+  }
+`)
+}
+
+func testErrorAssert_Snapshot_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Printf("%v:%v\n", line+2, line+6)
+	errorassert.Snapshot(t, testStruct{Field1: "a"}, `  assert_test.testStruct{
+      Field1: string("b")
+  }
+`)
+	errorassert.Snapshot(t, []byte("abc"), "abd")
+}
+
+func testEqual_GoSyntax_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Println(line + 2)
+	assert.Equal(t, []testStruct{{Field1: "a"}}, []testStruct{}, assert.GoSyntax())
+}
+
+func testEqual_SideBySide_Unexpected(t *testing.T) {
+	t.Setenv("ASSERT_SIDE_BY_SIDE", "40")
+
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Printf("%v:%v\n", line+2, line+3)
+	errorassert.Equal(t, []testStruct{{Field1: "a"}}, []testStruct{{Field1: "b"}}, assert.SideBySide(70))
+	errorassert.Equal(t, map[string]int{"a": 1}, map[string]int{"a": 2})
+}
+
+func testErrorAssert_Msg_With_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Printf("%v:%v:%v:%v\n", line+2, line+5, line+7, line+10)
+	errorassert.Nil(t, 1, assert.Msg("user %d", 1))
+
+	ctx := errorassert.With(t, "user", 2, "order")
+	errorassert.Equal(t, 1, 2, assert.Msg("totals"))
+	errorassert.With(t, "user", 3)
+	errorassert.IsSorted(t, []int{2, 1})
+	ctx.Close()
+	t.Run("sub", func(t *testing.T) {
+		errorassert.NoError(t, errors.New("err"))
+	})
+}
+
+func testAssertions_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Printf("%v:%v:%v:%v:%v:%v\n", line+4, line+5, line+8, line+9, line+16, line+17)
+	a := assert.New(t)
+
+	ok := a.OnFailure(assert.LogOnly).Equal(1, 2)
+	t.Log("log only", ok, t.Failed())
+
+	var called *testing.T
+	a.OnFailure(func(t *testing.T) { called = t }).Nil(1)
+	t.Log("custom", called == t, t.Failed())
+
+	t.Run("skip", func(t *testing.T) {
+		assert.New(t).OnFailure(assert.Skip).NoError(errors.New("precondition"))
+		t.Log("not reached")
+	})
+
+	a.OnFailure(assert.Fail).Greater(1, 2)
+	a.NotNil(nil)
+	t.Log("not reached")
+}
+
+func testSoft_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Printf("%v:%v:%v:%v:%v:%v:%v\n", line+7, line+2, line+4, line+6, line+9, line+13, line+12)
+	ok := assert.Soft(t, func(s *assert.SoftAssertions) {
+		s.Equal(1, 1)
+		s.Equal(1, 2)
+		assert.With(t, "n", 3)
+		s.Nil(1)
+		t.Log("after soft", s.Equal(1, 1))
+	})
+	t.Log("soft", ok, t.Failed())
+
+	s := assert.NewSoft(t)
+	s.Greater(1, 2)
+	s.VerifyNow()
+	t.Log("not reached")
+}
+
+func testGo_Unexpected(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	fmt.Printf("%v:%v:%v:%v:%v:%v:%v:%v:%v:%v\n", line+4, line+2, line+3, file, line+3, line+6, line+13, line+10, file, line+10)
+	ok := assert.Go(t, func(a *assert.Assertions) {
+		a.OnFailure(assert.Fail).Equal(1, 2)
+		t.Log("failed", t.Failed())
+	})
+	t.Log("go", ok, t.Failed())
+
+	c := assert.NewCollector(t)
+	c.Go(func(a *assert.Assertions) {
+		a.Nil(1)
+		t.Log("not reached")
+	})
+	c.Wait()
+	t.Log("not reached")
+}
+
+func testEqual_Expressions_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Printf("%v:%v\n", line+3, line+4)
+	items := []struct{ Price int }{{Price: 1}}
+	errorassert.Equal(t, items[0].Price, 2, assert.Expressions())
+	assert.New(t).Equal(len(items),
+		0, assert.Expressions(), assert.Msg("total"))
+}
+
+func testTrue_False_Condition_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Printf("%v:%v:%v\n", line+5, line+6, line+7)
+	users := []struct{ Active bool }{{}}
+	ok := true
+
+	errorassert.True(t, len(users) > 1 && users[0].Active, assert.Vars("users", users))
+	assert.New(t).OnFailure(assert.Fail).False(ok)
+	assert.Condition(t, func() bool { return len(users) == 0 }, assert.Vars("users", users))
+}
+
+func testTrue_False_Condition_Expected(t *testing.T) {
+	assert.True(t, true)
+	assert.False(t, false)
+	assert.Condition(t, func() bool { return true })
+}
+
+func testNoGoroutineLeaks_Unexpected(t *testing.T) {
+	block := make(chan struct{})
+	t.Cleanup(func() { close(block) })
+
+	t.Run("deferred", func(t *testing.T) {
+		_, file, line, _ := runtime.Caller(0)
+		defer assert.NoGoroutineLeaks(t)
+		ids := make(chan string)
+		go func() {
+			ids <- strings.Fields(string(debug.Stack()))[1]
+			<-block
+		}()
+		fmt.Printf("%v:%v:%v:%v:%v:%v\n", line+8, <-ids, file, line+3, file, line+5)
+	})
+}
+
+func testChannels_Unexpected(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Printf("%v:%v:%v:%v:%v:%v:%v\n", line+4, line+5, line+7, line+10, line+16, line+21, line+22)
+	ch := make(chan int, 3)
+	ch <- 1
+	errorassert.Receives(t, ch, 2)
+	errorassert.ReceivesWithin(t, ch, 3, 10*time.Millisecond)
+	ch <- 4
+	errorassert.NeverReceives(t, ch, 10*time.Millisecond)
+	ch <- 5
+	close(ch)
+	errorassert.Closed(t, ch)
+
+	ch = make(chan int, 3)
+	ch <- 1
+	ch <- 3
+	close(ch)
+	errorassert.ReceivesSequence(t, ch, []int{1, 2, 3}, time.Second)
+	ch = make(chan int, 3)
+	ch <- 4
+	ch <- 2
+	ch <- 1
+	errorassert.ReceivesUnordered(t, ch, []int{1, 2, 5}, time.Second)
+	assert.Closed(t, 1)
+}
+
+func testChannels_Expected(t *testing.T) {
+	ch := make(chan int, 3)
+	go func() {
+		ch <- 1
+		ch <- 2
+		ch <- 3
+		ch <- 4
+		ch <- 5
+		ch <- 6
+		close(ch)
+	}()
+	assert.Receives(t, ch, 1)
+	assert.ReceivesSequence(t, ch, []int{2, 3}, time.Second)
+	assert.ReceivesUnordered(t, ch, [2]int{5, 4}, time.Second)
+	assert.New(t).ReceivesWithin(ch, assert.InRange(6, 10), time.Second)
+	assert.Closed(t, ch)
+	assert.NeverReceives(t, make(chan int), 10*time.Millisecond)
+}
+
 func testNotEqual_Expected(t *testing.T) {
 	_, _, line, _ := runtime.Caller(0)
 	fmt.Println(line + 2)
@@ -289,7 +1054,12 @@ func TestRun(t *testing.T) {
 
 func execTest(fn func(t *testing.T)) (_ string, isExitError bool, _ error) {
 	cmd := exec.Command("go", "test", "-count=1", "-run", "^TestRun$")
-	cmd.Env = os.Environ()
+	// Reports of the expected failures would change the output.
+	for _, env := range os.Environ() {
+		if !strings.HasPrefix(env, "ASSERT_") {
+			cmd.Env = append(cmd.Env, env)
+		}
+	}
 	cmd.Env = append(cmd.Env,
 		fmt.Sprintf("%s=%s", TestRunNameEnvKey, funcName(fn)),
 	)
@@ -0,0 +1,297 @@
+package assert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-repo/assert/internal"
+)
+
+// Strategy is called with the test when an assertion of Assertions fails.
+type Strategy func(t *testing.T)
+
+// Strategies of Assertions.
+var (
+	// FailNow marks the test as failed and stops it, it's the strategy of
+	// the functions of this package.
+	FailNow Strategy = func(t *testing.T) { t.FailNow() }
+	// Fail marks the test as failed and continues it, it's the strategy of
+	// the functions of errorassert.
+	Fail Strategy = func(t *testing.T) { t.Fail() }
+	// Skip stops and skips the test, e.g. for preconditions which are not
+	// met.
+	Skip Strategy = func(t *testing.T) { t.SkipNow() }
+	// LogOnly only logs the failure message.
+	LogOnly Strategy = func(t *testing.T) {}
+)
+
+// Assertions of a test with a strategy for failures, the methods return
+// whether the assertion passed. As isn't a method since methods can't have
+// type parameters, AsOf takes the assertions instead.
+type Assertions struct {
+	t        *testing.T
+	strategy Strategy
+	// Options of every assertion.
+	opts []Option
+	// Called instead of the strategy by assertions of a Collector.
+	worker func(strategy Strategy)
+}
+
+// New returns the assertions of t which stop the test on failure.
+func New(t *testing.T) *Assertions {
+	return &Assertions{t: t, strategy: FailNow}
+}
+
+// OnFailure returns a copy of the assertions with another strategy, which
+// can also be a custom function.
+func (a *Assertions) OnFailure(strategy Strategy) *Assertions {
+	return &Assertions{t: a.t, strategy: strategy, opts: a.opts, worker: a.worker}
+}
+
+func (a *Assertions) options(opts []Option) []Option {
+	if len(a.opts) == 0 {
+		return opts
+	}
+	return append(a.opts[:len(a.opts):len(a.opts)], opts...)
+}
+
+func (a *Assertions) check(ok bool) bool {
+	a.t.Helper()
+
+	if !ok {
+		if a.worker != nil {
+			a.worker(a.strategy)
+		} else {
+			a.strategy(a.t)
+		}
+	}
+	return ok
+}
+
+func (a *Assertions) Equal(actual, expected interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.Equal(a.t, actual, expected, a.options(opts)...))
+}
+
+func (a *Assertions) NotEqual(actual, expected interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.NotEqual(a.t, actual, expected, a.options(opts)...))
+}
+
+func (a *Assertions) NoError(err error, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.NoError(a.t, err, a.options(opts)...))
+}
+
+func (a *Assertions) Nil(actual interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.Nil(a.t, actual, a.options(opts)...))
+}
+
+func (a *Assertions) NotNil(actual interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.NotNil(a.t, actual, a.options(opts)...))
+}
+
+func (a *Assertions) True(value bool, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.True(a.t, value, a.options(opts)...))
+}
+
+func (a *Assertions) False(value bool, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.False(a.t, value, a.options(opts)...))
+}
+
+func (a *Assertions) Condition(condition func() bool, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.Condition(a.t, condition, a.options(opts)...))
+}
+
+func (a *Assertions) InDelta(actual, expected interface{}, delta float64, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.InDelta(a.t, actual, expected, delta, a.options(opts)...))
+}
+
+func (a *Assertions) InEpsilon(actual, expected interface{}, epsilon float64, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.InEpsilon(a.t, actual, expected, epsilon, a.options(opts)...))
+}
+
+func (a *Assertions) WithinULP(actual, expected interface{}, ulps uint64, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.WithinULP(a.t, actual, expected, ulps, a.options(opts)...))
+}
+
+func (a *Assertions) WithinDuration(actual, expected time.Time, delta time.Duration, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.WithinDuration(a.t, actual, expected, delta, a.options(opts)...))
+}
+
+func (a *Assertions) TimeEqual(actual, expected time.Time, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.TimeEqual(a.t, actual, expected, a.options(opts)...))
+}
+
+func (a *Assertions) Greater(actual, expected interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.Greater(a.t, actual, expected, a.options(opts)...))
+}
+
+func (a *Assertions) GreaterOrEqual(actual, expected interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.GreaterOrEqual(a.t, actual, expected, a.options(opts)...))
+}
+
+func (a *Assertions) Less(actual, expected interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.Less(a.t, actual, expected, a.options(opts)...))
+}
+
+func (a *Assertions) LessOrEqual(actual, expected interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.LessOrEqual(a.t, actual, expected, a.options(opts)...))
+}
+
+func (a *Assertions) Between(actual, min, max interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.Between(a.t, actual, min, max, a.options(opts)...))
+}
+
+func (a *Assertions) IsSorted(slice interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.IsSorted(a.t, slice, a.options(opts)...))
+}
+
+func (a *Assertions) IsSortedBy(slice interface{}, less func(i, j int) bool, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.IsSortedBy(a.t, slice, less, a.options(opts)...))
+}
+
+func (a *Assertions) IsStrictlyIncreasing(slice interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.IsStrictlyIncreasing(a.t, slice, a.options(opts)...))
+}
+
+func (a *Assertions) IsType(expectedType, actual interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.IsType(a.t, expectedType, actual, a.options(opts)...))
+}
+
+func (a *Assertions) Implements(interfaceObject, actual interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.Implements(a.t, interfaceObject, actual, a.options(opts)...))
+}
+
+func (a *Assertions) AssignableTo(expectedType, actual interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.AssignableTo(a.t, expectedType, actual, a.options(opts)...))
+}
+
+func (a *Assertions) ConvertibleTo(expectedType, actual interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.ConvertibleTo(a.t, expectedType, actual, a.options(opts)...))
+}
+
+// AsOf returns actual as type T and whether it has this type, it's As for
+// the assertions a.
+func AsOf[T any](a *Assertions, actual interface{}, opts ...Option) (T, bool) {
+	a.t.Helper()
+
+	v, ok := internal.As[T](a.t, actual, a.options(opts)...)
+	return v, a.check(ok)
+}
+
+// JSONEq decodes actual and expected JSON, which can be a string, []byte,
+// json.RawMessage or io.Reader, and checks they are semantically equal.
+func (a *Assertions) JSONEq(actual, expected interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.JSONEq(a.t, actual, expected, a.options(opts)...))
+}
+
+// Snapshot compares actual with the inline snapshot expected, bytes and
+// strings are compared as is, other values as formatted by diff.Sprint.
+// With UPDATE_SNAPSHOTS=1, or the -golden.update flag of the golden
+// package, expected is rewritten in the source file of the caller, so it
+// must be a string literal.
+func (a *Assertions) Snapshot(actual interface{}, expected string, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.Snapshot(a.t, actual, expected, a.options(opts)...))
+}
+
+func (a *Assertions) Receives(ch, expected interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.Receives(a.t, ch, expected, a.options(opts)...))
+}
+
+func (a *Assertions) ReceivesWithin(ch, expected interface{}, timeout time.Duration, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.ReceivesWithin(a.t, ch, expected, timeout, a.options(opts)...))
+}
+
+func (a *Assertions) NeverReceives(ch interface{}, window time.Duration, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.NeverReceives(a.t, ch, window, a.options(opts)...))
+}
+
+func (a *Assertions) Closed(ch interface{}, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.Closed(a.t, ch, a.options(opts)...))
+}
+
+func (a *Assertions) ReceivesSequence(ch, expected interface{}, timeout time.Duration, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.ReceivesSequence(a.t, ch, expected, timeout, a.options(opts)...))
+}
+
+func (a *Assertions) ReceivesUnordered(ch, expected interface{}, timeout time.Duration, opts ...Option) bool {
+	a.t.Helper()
+
+	return a.check(internal.ReceivesUnordered(a.t, ch, expected, timeout, a.options(opts)...))
+}
+
+// NoGoroutineLeaks snapshots the goroutines and fails when the test ends if
+// goroutines started by the test or since the snapshot didn't exit.
+func (a *Assertions) NoGoroutineLeaks(opts ...Option) {
+	a.t.Helper()
+
+	snapshot := internal.Goroutines()
+	test := internal.CurrentGoroutine()
+	a.t.Cleanup(func() {
+		a.t.Helper()
+
+		a.check(internal.NoGoroutineLeaks(a.t, snapshot, test, a.options(opts)...))
+	})
+}
@@ -0,0 +1,111 @@
+package assert
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-repo/assert/internal"
+)
+
+// Collector runs assertions in goroutines other than the one of the test,
+// the failures are collected with the stacks of their goroutines and
+// replayed on the test goroutine by Wait.
+type Collector struct {
+	t  *testing.T
+	wg sync.WaitGroup
+
+	mu       sync.Mutex
+	failures []workerFailure
+}
+
+type workerFailure struct {
+	failure  internal.Failure
+	stack    string
+	strategy Strategy
+}
+
+// NewCollector returns a collector of the assertions of t.
+func NewCollector(t *testing.T) *Collector {
+	return &Collector{t: t}
+}
+
+// Go calls fn with assertions of the test in a new goroutine. A failure
+// with the strategy FailNow or Skip stops the goroutine, the strategy is
+// called on the test goroutine by Wait.
+func (c *Collector) Go(fn func(a *Assertions)) {
+	var last workerFailure
+	a := &Assertions{
+		t:        c.t,
+		strategy: FailNow,
+		opts: []Option{internal.Collect(func(f internal.Failure) {
+			last = workerFailure{failure: f, stack: internal.Stack()}
+		})},
+		worker: func(strategy Strategy) {
+			last.strategy = strategy
+			c.add(last)
+			if sameStrategy(strategy, FailNow) || sameStrategy(strategy, Skip) {
+				runtime.Goexit()
+			}
+		},
+	}
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		fn(a)
+	}()
+}
+
+func (c *Collector) add(f workerFailure) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.failures = append(c.failures, f)
+}
+
+// Wait waits for the goroutines started by Go, logs their failures and then
+// calls the strategies of the failures, it returns whether there were no
+// failures.
+func (c *Collector) Wait() bool {
+	c.t.Helper()
+
+	c.wg.Wait()
+
+	c.mu.Lock()
+	failures := c.failures
+	c.failures = nil
+	c.mu.Unlock()
+
+	for _, f := range failures {
+		c.t.Log(sprintWorkerFailure(f))
+	}
+	for _, f := range failures {
+		f.strategy(c.t)
+	}
+	return len(failures) == 0
+}
+
+// Go calls fn with assertions of t in a new goroutine and waits for it, see
+// Collector.
+func Go(t *testing.T, fn func(a *Assertions)) bool {
+	t.Helper()
+
+	c := NewCollector(t)
+	c.Go(fn)
+	return c.Wait()
+}
+
+func sameStrategy(x, y Strategy) bool {
+	return reflect.ValueOf(x).Pointer() == reflect.ValueOf(y).Pointer()
+}
+
+func sprintWorkerFailure(f workerFailure) string {
+	return fmt.Sprintf("%s:%v: %s\nGoroutine stack:\n%s", filepath.Base(f.failure.File), f.failure.Line,
+		f.failure.Message, strings.TrimRight(f.stack, "\n"))
+}
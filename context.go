@@ -0,0 +1,19 @@
+package assert
+
+import (
+	"testing"
+
+	"github.com/go-repo/assert/internal"
+)
+
+// Context of key/value pairs which are prefixed to the failure messages of
+// a test and its subtests until it's closed or the test ends.
+type Context = internal.Context
+
+// With adds key/value pairs to the failure messages of all assertions of
+// the test and its subtests, like "user=1: Expected nil but got: ...". A
+// later value of a key replaces the former one, so in a loop the context
+// doesn't have to be closed.
+func With(t *testing.T, keysAndValues ...interface{}) *Context {
+	return internal.With(t, keysAndValues...)
+}
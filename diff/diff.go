@@ -1,8 +1,8 @@
 package diff
 
 import (
-	"bytes"
 	"fmt"
+	"io"
 	"reflect"
 	"strings"
 
@@ -11,143 +11,57 @@ import (
 
 const indent = "    "
 
-func sprintDiffXYWithKey(
-	prefix string,
-	deep, ptrDeep int,
-	key, typ, val string,
-) string {
-	if typ != "" {
-		var format string
-		if ptrDeep > 0 {
-			format = "%s %s%s: %s(%s)(%s)\n"
-		} else {
-			format = "%s %s%s: %s%s(%s)\n"
-		}
-
-		return fmt.Sprintf(format,
-			prefix,
-			strings.Repeat(indent, deep),
-			key,
-			strings.Repeat("*", ptrDeep),
-			typ,
-			val,
-		)
-	} else {
-		return fmt.Sprintf("%s %s%s: %s%s\n",
-			prefix,
-			strings.Repeat(indent, deep),
-			key,
-			strings.Repeat("*", ptrDeep),
-			val,
-		)
-	}
-}
+type (
+	Node   = internal.Node
+	DiffXY = internal.DiffXY
+	XY     = internal.XY
+)
 
-func sprintDiffXY(
-	prefix string,
-	deep, ptrDeep int,
-	typ, val string,
-) string {
-	if typ != "" {
-		var format string
-		if ptrDeep > 0 {
-			format = "%s %s%s(%s)(%s)\n"
-		} else {
-			format = "%s %s%s%s(%s)\n"
+// Matcher can be placed anywhere in the expected value to match the actual
+// value by a rule instead of by equality. Match returns nil if the actual
+// value matches, otherwise an error explaining why it does not, String
+// describes the expected value in the diff.
+type Matcher = internal.Matcher
+
+func (p *printer) diffXY(node *internal.Node, deep, ptrDeep int) {
+	x, y := node.DiffXY.X, node.DiffXY.Y
+	if x != nil {
+		note := ""
+		if y == nil {
+			note = node.DiffXY.Note
 		}
-
-		return fmt.Sprintf(format,
-			prefix,
-			strings.Repeat(indent, deep),
-			strings.Repeat("*", ptrDeep),
-			typ,
-			val,
-		)
-	} else {
-		return fmt.Sprintf("%s %s%s%s\n",
-			prefix,
-			strings.Repeat(indent, deep),
-			strings.Repeat("*", ptrDeep),
-			val,
-		)
+		p.leaf("-", deep, ptrDeep, node.Key, x, note)
 	}
-}
-
-func diffXYStr(node *internal.Node, deep, ptrDeep int) string {
-	var str string
-	if node.Key != "" {
-		if node.DiffXY.X != nil {
-			str = str + sprintDiffXYWithKey("-", deep, ptrDeep, node.Key,
-				node.DiffXY.X.Type, node.DiffXY.X.Val)
-		}
-		if node.DiffXY.Y != nil {
-			str = str + sprintDiffXYWithKey("+", deep, ptrDeep, node.Key,
-				node.DiffXY.Y.Type, node.DiffXY.Y.Val)
-		}
-	} else {
-		if node.DiffXY.X != nil {
-			str = str + sprintDiffXY("-", deep, ptrDeep,
-				node.DiffXY.X.Type, node.DiffXY.X.Val)
-		}
-		if node.DiffXY.Y != nil {
-			str = str + sprintDiffXY("+", deep, ptrDeep,
-				node.DiffXY.Y.Type, node.DiffXY.Y.Val)
-		}
+	if y != nil {
+		p.leaf("+", deep, ptrDeep, node.Key, y, node.DiffXY.Note)
 	}
-	return str
 }
 
-func levelStrWithKey(deep, ptrDeep int, key, typ string) string {
-	return fmt.Sprintf("  %s%s: %s%s{\n",
-		strings.Repeat(indent, deep),
-		key,
-		strings.Repeat("&", ptrDeep),
-		typ,
-	)
-}
-
-func levelStr(deep, ptrDeep int, typ string) string {
-	return fmt.Sprintf("  %s%s%s{\n",
-		strings.Repeat(indent, deep),
-		strings.Repeat("&", ptrDeep),
-		typ,
-	)
-}
-
-func sprintTree(node *internal.Node, deep int, ptrDeep *int, buffer *bytes.Buffer) {
+func (p *printer) diffTree(node *internal.Node, deep int, ptrDeep *int) {
 	for _, child := range node.Children {
 		if child.DiffXY == nil && child.DiffNum == 0 {
 			continue
 		}
 
 		if child.DiffXY != nil {
-			buffer.WriteString(diffXYStr(child, deep, *ptrDeep))
+			p.diffXY(child, deep, *ptrDeep)
 			*ptrDeep = 0
 			continue
 		}
 
 		switch child.Kind {
 		case reflect.Array, reflect.Map, reflect.Slice, reflect.Struct:
-			if child.Key != "" {
-				buffer.WriteString(
-					levelStrWithKey(deep, *ptrDeep, child.Key, child.Type),
-				)
-			} else {
-				buffer.WriteString(
-					levelStr(deep, *ptrDeep, child.Type),
-				)
-			}
-
+			p.level(deep, *ptrDeep, child.Key, child.Type)
 			*ptrDeep = 0
 
-			sprintTree(child, deep+1, ptrDeep, buffer)
+			p.diffTree(child, deep+1, ptrDeep)
 
-			buffer.WriteString(strings.Repeat(indent, deep) + "  }\n")
+			p.levelEnd(deep)
 		case reflect.Ptr:
 			*ptrDeep = *ptrDeep + 1
-			sprintTree(child, deep, ptrDeep, buffer)
+			p.diffTree(child, deep, ptrDeep)
 		case reflect.Interface:
-			sprintTree(child, deep, ptrDeep, buffer)
+			p.diffTree(child, deep, ptrDeep)
 		default:
 			panic(fmt.Sprintf("%v kind should be handled as level", child.Kind.String()))
 		}
@@ -165,14 +79,54 @@ func calcNodeDiffNum(node *internal.Node) int {
 	return node.DiffNum
 }
 
-func Diff(x, y interface{}) string {
-	tree := internal.Diff(x, y)
-
+// Tree returns the diff tree of x and y, a node without DiffXY is a level
+// like a struct or a map, a node with DiffXY is a leaf where x and y differ.
+// Levels without differences have zero DiffNum.
+func Tree(x, y interface{}, opts ...Option) *Node {
+	o := newOptions(opts)
+	tree := internal.Diff(x, y, o)
 	calcNodeDiffNum(tree)
 
-	buffer := bytes.NewBuffer(nil)
+	if o.HideTypes {
+		internal.HideTypes(tree)
+	}
+	return tree
+}
+
+// Fdiff writes the diff of x and y to w line by line while walking the diff
+// tree, lines of actual x start with "-" and lines of expected y with "+".
+// With SideBySide, x and y are written in two columns instead.
+func Fdiff(w io.Writer, x, y interface{}, opts ...Option) error {
+	return FdiffTree(w, Tree(x, y, opts...), opts...)
+}
+
+// FdiffTree writes a diff tree returned by Tree like Fdiff, so a caller which
+// needs both doesn't diff the values twice.
+func FdiffTree(w io.Writer, tree *Node, opts ...Option) error {
+	o := newOptions(opts)
+
+	p := newPrinter(w)
 	ptrDeep := 0
-	sprintTree(tree, 0, &ptrDeep, buffer)
+	if o.SideBySide {
+		p.sideBySideTree(tree, 0, &ptrDeep, columnWidth(o.Width))
+	} else {
+		p.diffTree(tree, 0, &ptrDeep)
+	}
+
+	return p.flush()
+}
+
+// Diff returns the diff of x and y written by Fdiff, it's empty if they are
+// equal.
+func Diff(x, y interface{}, opts ...Option) string {
+	builder := &strings.Builder{}
+	_ = Fdiff(builder, x, y, opts...)
+	return builder.String()
+}
 
-	return buffer.String()
+// DiffTree returns the diff of a diff tree written by FdiffTree.
+func DiffTree(tree *Node, opts ...Option) string {
+	builder := &strings.Builder{}
+	_ = FdiffTree(builder, tree, opts...)
+	return builder.String()
 }
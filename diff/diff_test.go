@@ -1,13 +1,16 @@
 package diff
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"reflect"
+	"strconv"
 	"testing"
+	"time"
 	"unsafe"
-
-	"github.com/go-repo/assert/diff/internal"
-	"github.com/go-repo/assert/diff/test/draw_tree"
 )
 
 type S1 struct {
@@ -441,38 +444,537 @@ func TestDiff__ReferenceCycle(t *testing.T) {
 	}
 }
 
-// The test can generate a tree graph, used to analyze tree structure.
-func testDrawTree(t *testing.T) {
-	yBool := true
-	yPtrBool := &yBool
-	yPtrPtrBool := &yPtrBool
+type graphT struct {
+	A S4
+	B []string
+	C map[string]int
+}
 
-	x := &S3{}
-	y := &S3{
-		pppBool: &yPtrPtrBool,
-		pInt:    ptrInt(1),
-		pUint64: ptrUint64(2),
-		pMapping: &map[S4]*S4{
-			S4{int: 3}: nil,
-		},
-		pArray: &[2]float32{4},
-		pSlice: &[]string{"5"},
-		pStru: &S4{
-			int: 6,
+func graphTree() *Node {
+	return Tree(
+		graphT{A: S4{int: 1}, B: []string{`a"b`}, C: map[string]int{"k": 1}},
+		graphT{A: S4{int: 1}, B: []string{"c", "d"}, C: map[string]int{}},
+	)
+}
+
+func TestWriteDOT(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	err := WriteDOT(buffer, graphTree())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `digraph DiffTree {
+node [shape="box", fontname="menlo"]
+n0 [label="key: \lkind: invalid\ltype: \ldiff_num: 3\l"]
+n0 -> n1
+n1 [label="key: \lkind: struct\ltype: diff.graphT\ldiff_num: 3\l"]
+n1 -> n2
+n2 [label="key: A\lkind: struct\ltype: diff.S4\ldiff_num: 0\l", style="filled", fillcolor="#f6f8fa", color="#8c959f"]
+n1 -> n3
+n3 [label="key: B\lkind: slice\ltype: []string\ldiff_num: 2\l"]
+n3 -> n4
+n4 [label="key: 0\lx: string(\"a\\\"b\")\ly: string(\"c\")\l", style="filled", fillcolor="#fff8c5", color="#9a6700"]
+n3 -> n5
+n5 [label="key: 1\ly: string(\"d\")\l", style="filled", fillcolor="#e6ffec", color="#1a7f37"]
+n1 -> n6
+n6 [label="key: C\lkind: map\ltype: map[string]int\ldiff_num: 1\l"]
+n6 -> n7
+n7 [label="key: \"k\"\lx: int(1)\l", style="filled", fillcolor="#ffebe9", color="#cf222e"]
+}
+`
+	if buffer.String() != expected {
+		t.Fatalf("expected:\n%s\nbut got:\n%s", expected, buffer.String())
+	}
+}
+
+func TestWriteMermaid(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	err := WriteMermaid(buffer, graphTree(), PruneUnchanged())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `graph TD
+    n0["key: <br/>kind: invalid<br/>type: <br/>diff_num: 3"]
+    n0 --> n1
+    n1["key: <br/>kind: struct<br/>type: diff.graphT<br/>diff_num: 3"]
+    n1 --> n2
+    n2["key: B<br/>kind: slice<br/>type: []string<br/>diff_num: 2"]
+    n2 --> n3
+    n3["key: 0<br/>x: string(#quot;a\#quot;b#quot;)<br/>y: string(#quot;c#quot;)"]
+    n2 --> n4
+    n4["key: 1<br/>y: string(#quot;d#quot;)"]
+    n1 --> n5
+    n5["key: C<br/>kind: map<br/>type: map[string]int<br/>diff_num: 1"]
+    n5 --> n6
+    n6["key: #quot;k#quot;<br/>x: int(1)"]
+    classDef removed fill:#ffebe9,stroke:#cf222e
+    class n6 removed
+    classDef added fill:#e6ffec,stroke:#1a7f37
+    class n4 added
+    classDef changed fill:#fff8c5,stroke:#9a6700
+    class n3 changed
+    classDef unchanged fill:#f6f8fa,stroke:#8c959f
+`
+	if buffer.String() != expected {
+		t.Fatalf("expected:\n%s\nbut got:\n%s", expected, buffer.String())
+	}
+}
+
+type oddMatcher struct{}
+
+func (oddMatcher) Match(actual interface{}) error {
+	i, ok := actual.(int)
+	if !ok {
+		return fmt.Errorf("got %T", actual)
+	}
+	if i%2 == 0 {
+		return fmt.Errorf("%v is even", i)
+	}
+	return nil
+}
+
+func (oddMatcher) String() string {
+	return "Odd()"
+}
+
+type S6 struct {
+	inter   interface{}
+	mapping map[string]interface{}
+	slice   []interface{}
+}
+
+func TestDiff__Matcher(t *testing.T) {
+	x := S6{
+		inter:   1,
+		mapping: map[string]interface{}{"a": 3, "b": 4},
+		slice:   []interface{}{5, "6", nil},
+	}
+	y := S6{
+		inter:   oddMatcher{},
+		mapping: map[string]interface{}{"a": oddMatcher{}, "b": oddMatcher{}},
+		slice:   []interface{}{oddMatcher{}, oddMatcher{}, oddMatcher{}},
+	}
+
+	diff := Diff(x, y)
+	expectedDiff := `  diff.S6{
+      mapping: map[string]interface {}{
+-         "b": int(4)
++         "b": Odd() // 4 is even
+      }
+      slice: []interface {}{
+-         1: string("6")
++         1: Odd() // got string
+-         2: <nil>
++         2: Odd() // got <nil>
+      }
+  }
+`
+	if diff != expectedDiff {
+		t.Fatal()
+	}
+
+	if Diff(7, oddMatcher{}) != "" {
+		t.Fatal()
+	}
+
+	diff = Diff(
+		S6{mapping: map[string]interface{}{}, slice: []interface{}{1}},
+		S6{mapping: map[string]interface{}{"a": oddMatcher{}}, slice: []interface{}{1, oddMatcher{}}},
+	)
+	expectedDiff = `  diff.S6{
+      mapping: map[string]interface {}{
++         "a": Odd()
+      }
+      slice: []interface {}{
++         1: Odd()
+      }
+  }
+`
+	if diff != expectedDiff {
+		t.Fatal(diff)
+	}
+}
+
+func TestDiff__FloatTolerance(t *testing.T) {
+	x := []float64{1, 100, math.NaN(), 1}
+	y := []float64{1.05, 101, math.NaN(), math.Nextafter(1, 2)}
+
+	diff := Diff(x, y, FloatDelta(0.1))
+	expectedDiff := `  []float64{
+-     1: float64(100)
++     1: float64(101) // delta 1 > 0.1
+-     2: float64(NaN)
++     2: float64(NaN)
+  }
+`
+	if diff != expectedDiff {
+		t.Fatal(diff)
+	}
+
+	diff = Diff(x, y, FloatDelta(0.1), FloatEpsilon(0.01), EquateNaNs())
+	if diff != "" {
+		t.Fatal(diff)
+	}
+
+	diff = Diff(x, y, FloatULP(1))
+	expectedDiff = `  []float64{
+-     0: float64(1)
++     0: float64(1.05) // ulp 225179981368525 > 1
+-     1: float64(100)
++     1: float64(101) // ulp 70368744177664 > 1
+-     2: float64(NaN)
++     2: float64(NaN)
+  }
+`
+	if diff != expectedDiff {
+		t.Fatal(diff)
+	}
+
+	diff = Diff(float32(1), math.Nextafter32(1, 2), FloatULP(1))
+	if diff != "" {
+		t.Fatal(diff)
+	}
+
+	diff = Diff(complex(1, 1), complex(1, 1.5), FloatDelta(0.1))
+	if diff != "- complex128((1+1i))\n+ complex128((1+1.5i)) // delta 0.5 > 0.1\n" {
+		t.Fatal(diff)
+	}
+}
+
+type S7 struct {
+	createdAt time.Time
+	updatedAt *time.Time
+}
+
+func TestDiff__Time(t *testing.T) {
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	tmLater := tm.Add(1500 * time.Millisecond)
+	x := S7{createdAt: tm, updatedAt: &tm}
+	y := S7{createdAt: tm.In(time.FixedZone("UTC+8", 8*3600)), updatedAt: &tmLater}
+
+	diff := Diff(x, y)
+	expectedDiff := `  diff.S7{
+-     createdAt: time.Time(2020-01-02T03:04:05.123456789Z)
++     createdAt: time.Time(2020-01-02T11:04:05.123456789+08:00) // same instant in different locations
+-     updatedAt: *(time.Time)(2020-01-02T03:04:05.123456789Z)
++     updatedAt: *(time.Time)(2020-01-02T03:04:06.623456789Z) // skew -1.5s
+  }
+`
+	if diff != expectedDiff {
+		t.Fatal(diff)
+	}
+
+	diff = Diff(x, y, TimeTolerance(time.Second))
+	expectedDiff = `  diff.S7{
+-     updatedAt: *(time.Time)(2020-01-02T03:04:05.123456789Z)
++     updatedAt: *(time.Time)(2020-01-02T03:04:06.623456789Z) // skew -1.5s > 1s
+  }
+`
+	if diff != expectedDiff {
+		t.Fatal(diff)
+	}
+
+	diff = Diff(x, y, TimeTolerance(2*time.Second))
+	if diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestDiff__MapKeysAreSorted(t *testing.T) {
+	x := map[interface{}]int{10: 1, 9: 1, "b": 1, "a": 1}
+	y := map[interface{}]int{10: 2, 9: 2, "b": 2, "c": 2}
+
+	diff := Diff(x, y)
+	expectedDiff := `  map[interface {}]int{
+-     9: int(1)
++     9: int(2)
+-     10: int(1)
++     10: int(2)
+-     "a": int(1)
+-     "b": int(1)
++     "b": int(2)
++     "c": int(2)
+  }
+`
+	if diff != expectedDiff {
+		t.Fatal(diff)
+	}
+}
+
+func TestSprint(t *testing.T) {
+	x := &S5{str: "1"}
+	x.self = x
+
+	s := Sprint(x)
+	if s != `  &diff.S5{
+      str: string("1")
+      self: *diff.S5(<cycle>)
+  }
+` {
+		t.Fatal(s)
+	}
+
+	s = Sprint(map[string]interface{}{"b": []int{1}, "a": nil, "c": &S4{int: 3}})
+	if s != `  map[string]interface {}{
+      "a": interface {}(nil)
+      "b": []int{
+          0: int(1)
+      }
+      "c": &diff.S4{
+          int: int(3)
+      }
+  }
+` {
+		t.Fatal(s)
+	}
+
+	if Sprint(nil) != "  <nil>\n" {
+		t.Fatal()
+	}
+}
+
+type S8 struct {
+	Int     int
+	Str     string
+	Slice   []string
+	Mapping map[string]interface{}
+	Ptr     *S8
+	Inter   interface{}
+	Time    time.Time
+	zero    float64
+}
+
+func TestGoSyntax(t *testing.T) {
+	i := 5
+	x := &S8{
+		Int:   1,
+		Slice: []string{"a", "b"},
+		Mapping: map[string]interface{}{
+			"z": nil,
+			"y": 1.0,
+			"x": int64(3),
+			"w": []int(nil),
+			"v": &i,
 		},
-		ch:            make(chan int),
-		fn:            func() {},
-		mapping:       map[string]bool{"7": true},
-		unsafePointer: unsafe.Pointer(&yBool),
-		inter:         S4{int: 8},
-		slice:         []S4{{int: 9}},
+		Ptr:   &S8{Str: "2"},
+		Inter: S4{int: 1},
+		Time:  time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC),
+	}
+	x.Ptr.Ptr = x
+
+	s := GoSyntax(x)
+	expected := `&diff.S8{
+	Int:   1,
+	Slice: []string{"a", "b"},
+	Mapping: map[string]interface{}{
+		"v": func() *int { v := 5; return &v }(),
+		"w": ([]int)(nil),
+		"x": int64(3),
+		"y": 1.0,
+		"z": nil,
+	},
+	Ptr: &diff.S8{
+		Str: "2",
+		Ptr: nil, /* cycle of *diff.S8 */
+	},
+	Inter: diff.S4{
+		int: 1,
+	},
+	Time: time.Date(2020, time.January, 2, 3, 4, 5, 6, time.UTC),
+}`
+	if s != expected {
+		t.Fatal(s)
+	}
+
+	for v, expected := range map[interface{}]string{
+		int64(3):          "int64(3)",
+		uint8(1):          "uint8(1)",
+		"s":               `"s"`,
+		complex64(1):      "complex64(complex(1.0, 0.0))",
+		[2]bool{true}:     "[2]bool{true, false}",
+		S2{}:              "diff.S2{}",
+		math.Inf(-1):      "math.Inf(-1)",
+		time.Duration(10): "time.Duration(10)",
+	} {
+		if s := GoSyntax(v); s != expected {
+			t.Fatal(s)
+		}
+	}
+
+	if GoSyntax(nil) != "nil" || GoSyntax([]int{}) != "[]int{}" {
+		t.Fatal()
+	}
+}
+
+func TestFprint__Options(t *testing.T) {
+	x := &S3{
+		pInt:    ptrInt(1),
+		pStru:   &S4{int: 2},
+		mapping: map[string]bool{"a": true},
+		slice:   []S4{{int: 3}},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	err := Fprint(buffer, x, MaxDepth(1), HideTypes())
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	tree := internal.Diff(x, y)
-	calcNodeDiffNum(tree)
+	expected := `  &{
+      pppBool: nil
+      pInt: *1
+      pUint64: nil
+      pMapping: nil
+      pArray: nil
+      pSlice: nil
+      pStru: &{...}
+      ch: nil
+      fn: nil
+      mapping: {...}
+      unsafePointer: nil
+      inter: nil
+      slice: {...}
+  }
+`
+	if buffer.String() != expected {
+		t.Fatal(buffer.String())
+	}
+
+	s := Sprint([]S4{{int: 3}}, MaxDepth(1))
+	if s != "  []diff.S4{\n      0: diff.S4{...}\n  }\n" {
+		t.Fatal(s)
+	}
+
+	diff := Diff(x, &S3{pInt: ptrInt(2)}, HideTypes())
+	expectedDiff := `  &{
+-     pInt: *1
++     pInt: *2
+-     pStru: &{2}
++     pStru: nil
+-     mapping: map[a:true]
++     mapping: nil
+-     slice: [{3}]
++     slice: nil
+  }
+`
+	if diff != expectedDiff {
+		t.Fatal(diff)
+	}
+}
+
+func TestDiff__SideBySide(t *testing.T) {
+	x := &S3{
+		pInt:    ptrInt(1),
+		mapping: map[string]bool{"a": true, "b": false},
+		slice:   []S4{{int: 3}, {int: 4}},
+	}
+	y := &S3{
+		pInt:    ptrInt(2),
+		mapping: map[string]bool{"a": true, "c": false},
+		slice:   []S4{{int: 3}},
+		inter:   "a very long string value which needs to be wrapped",
+	}
+
+	expected := `&diff.S3{                           &diff.S3{
+    pInt: *(int)(1)               |     pInt: *(int)(2)
+    mapping: map[string]bool{           mapping: map[string]bool{
+        "b": bool(false)          <
+                                  >         "c": bool(false)
+    }                                   }
+    inter: interface {}(nil)      |     inter: interface {}("a very l
+                                  | ong string value which needs to b
+                                  | e wrapped")
+    slice: []diff.S4{                   slice: []diff.S4{
+        1: diff.S4({4})           <
+    }                                   }
+}                                   }
+`
+	actual := Diff(x, y, SideBySide(70))
+	if actual != expected {
+		t.Fatalf("expected:\n%s\nbut got:\n%s", expected, actual)
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write error")
+}
 
-	err := draw_tree.DrawTreeToFile(tree, "tree.dot")
+func TestFdiff(t *testing.T) {
+	x, y := benchmarkValues(100)
+
+	buffer := bytes.NewBuffer(nil)
+	err := Fdiff(struct{ io.Writer }{buffer}, x, y)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if buffer.String() != Diff(x, y) {
+		t.Fatalf("Fdiff and Diff differ:\n%s\n%s", buffer.String(), Diff(x, y))
+	}
+
+	err = Fdiff(errWriter{}, x, y)
+	if err == nil || err.Error() != "write error" {
+		t.Fatalf("expected write error but got: %v", err)
+	}
+}
+
+func TestDiffTree(t *testing.T) {
+	x, y := benchmarkValues(10)
+
+	tree := Tree(x, y)
+	if DiffTree(tree) != Diff(x, y) {
+		t.Fatalf("DiffTree and Diff differ:\n%s\n%s", DiffTree(tree), Diff(x, y))
+	}
+	if DiffTree(tree, SideBySide(80)) != Diff(x, y, SideBySide(80)) {
+		t.Fatalf("DiffTree and Diff differ side by side:\n%s\n%s", DiffTree(tree, SideBySide(80)), Diff(x, y, SideBySide(80)))
+	}
+}
+
+func benchmarkValues(n int) (x, y []S3) {
+	for i := 0; i < n; i++ {
+		x = append(x, S3{
+			pInt:    ptrInt(i),
+			mapping: map[string]bool{"a": true, strconv.Itoa(i): true},
+			slice:   []S4{{int: i}, {int: i + 1}},
+		})
+		y = append(y, S3{
+			pInt:    ptrInt(i + 1),
+			mapping: map[string]bool{"a": false, strconv.Itoa(i): true},
+			slice:   []S4{{int: i}, {int: i + 2}},
+		})
+	}
+	return x, y
+}
+
+func BenchmarkDiff(b *testing.B) {
+	x, y := benchmarkValues(1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		Diff(x, y)
+	}
+}
+
+func BenchmarkFdiff(b *testing.B) {
+	x, y := benchmarkValues(1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = Fdiff(io.Discard, x, y)
+	}
+}
+
+func BenchmarkSprint(b *testing.B) {
+	x, _ := benchmarkValues(1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		Sprint(x)
+	}
 }
@@ -0,0 +1,255 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-repo/assert/diff/internal"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Max length of a slice literal of scalars which is kept on one line.
+const maxOneLineLen = 80
+
+type goSyntaxPrinter struct {
+	visited map[uintptr]bool
+}
+
+// isUntypedDefault reports whether an untyped constant literal of a value
+// has the type of the value by default.
+func isUntypedDefault(t reflect.Type) bool {
+	switch t {
+	case reflect.TypeOf(false), reflect.TypeOf(0), reflect.TypeOf(""),
+		reflect.TypeOf(0.0), reflect.TypeOf(complex128(0)):
+		return true
+	}
+	return false
+}
+
+func sprintGoFloat(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return "math.NaN()"
+	case math.IsInf(f, 1):
+		return "math.Inf(1)"
+	case math.IsInf(f, -1):
+		return "math.Inf(-1)"
+	}
+
+	s := strconv.FormatFloat(f, 'g', -1, bitSize)
+	if !strings.ContainsAny(s, ".e") {
+		s = s + ".0"
+	}
+	return s
+}
+
+func sprintGoTime(tm time.Time) string {
+	var loc string
+	switch tm.Location() {
+	case time.UTC:
+		loc = "time.UTC"
+	case time.Local:
+		loc = "time.Local"
+	default:
+		name, offset := tm.Zone()
+		loc = fmt.Sprintf("time.FixedZone(%q, %d)", name, offset)
+	}
+
+	return fmt.Sprintf("time.Date(%d, time.%s, %d, %d, %d, %d, %d, %s)",
+		tm.Year(), tm.Month(), tm.Day(),
+		tm.Hour(), tm.Minute(), tm.Second(), tm.Nanosecond(),
+		loc,
+	)
+}
+
+// scalar returns the literal of a bool, number or string.
+func (p *goSyntaxPrinter) scalar(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return sprintGoFloat(v.Float(), v.Type().Bits())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		return fmt.Sprintf("complex(%s, %s)",
+			sprintGoFloat(real(c), v.Type().Bits()/2),
+			sprintGoFloat(imag(c), v.Type().Bits()/2),
+		)
+	case reflect.String:
+		return strconv.Quote(v.String())
+	}
+	return ""
+}
+
+// value returns the literal of v, typed is true if the context of v has no
+// type, like an interface, so the literal must carry the type of v.
+func (p *goSyntaxPrinter) value(v reflect.Value, typed bool) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+
+	if v.Type() == timeType {
+		return sprintGoTime(internal.ValueInterface(v).(time.Time))
+	}
+
+	switch v.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.String:
+		s := p.scalar(v)
+		if typed && !isUntypedDefault(v.Type()) {
+			return fmt.Sprintf("%s(%s)", v.Type(), s)
+		}
+		return s
+	case reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+		return p.value(v.Elem(), true)
+	case reflect.Ptr:
+		return p.pointer(v, typed)
+	case reflect.Array:
+		return p.elems(v, v.Type().String())
+	case reflect.Slice:
+		if v.IsNil() {
+			return p.nilValue(v, typed)
+		}
+		return p.elems(v, v.Type().String())
+	case reflect.Map:
+		if v.IsNil() {
+			return p.nilValue(v, typed)
+		}
+		return p.mapValue(v)
+	case reflect.Struct:
+		return p.structValue(v)
+	}
+
+	// Channels, functions and unsafe pointers have no literal.
+	if v.IsNil() {
+		return p.nilValue(v, typed)
+	}
+	return fmt.Sprintf("nil /* %s */", v.Type())
+}
+
+func (p *goSyntaxPrinter) nilValue(v reflect.Value, typed bool) string {
+	if typed {
+		return fmt.Sprintf("(%s)(nil)", v.Type())
+	}
+	return "nil"
+}
+
+func (p *goSyntaxPrinter) pointer(v reflect.Value, typed bool) string {
+	if v.IsNil() {
+		return p.nilValue(v, typed)
+	}
+
+	if p.visited[v.Pointer()] {
+		return fmt.Sprintf("nil /* cycle of %s */", v.Type())
+	}
+	p.visited[v.Pointer()] = true
+	defer delete(p.visited, v.Pointer())
+
+	elem := v.Elem()
+	switch elem.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.Struct:
+		if elem.Type() != timeType && (elem.Kind() == reflect.Array || elem.Kind() == reflect.Struct || !elem.IsNil()) {
+			return "&" + p.value(elem, false)
+		}
+	}
+
+	// There is no literal for a pointer to a scalar.
+	return fmt.Sprintf("func() %s { v := %s; return &v }()", v.Type(), p.value(elem, true))
+}
+
+func (p *goSyntaxPrinter) elems(v reflect.Value, typ string) string {
+	var elems []string
+	oneLine := true
+	length := len(typ)
+	for i := 0; i < v.Len(); i++ {
+		s := p.value(v.Index(i), v.Type().Elem().Kind() == reflect.Interface)
+		elems = append(elems, s)
+
+		length += len(s) + 2
+		if strings.Contains(s, "\n") || strings.Contains(s, "{") {
+			oneLine = false
+		}
+	}
+
+	if len(elems) == 0 {
+		return typ + "{}"
+	}
+	if oneLine && length <= maxOneLineLen {
+		return typ + "{" + strings.Join(elems, ", ") + "}"
+	}
+	return typ + "{\n" + strings.Join(elems, ",\n") + ",\n}"
+}
+
+func (p *goSyntaxPrinter) mapValue(v reflect.Value) string {
+	if v.Len() == 0 {
+		return v.Type().String() + "{}"
+	}
+
+	elemTyped := v.Type().Elem().Kind() == reflect.Interface
+	keyTyped := v.Type().Key().Kind() == reflect.Interface
+
+	buffer := bytes.NewBuffer(nil)
+	buffer.WriteString(v.Type().String() + "{\n")
+	for _, k := range internal.SortedMapKeys(v) {
+		buffer.WriteString(fmt.Sprintf("%s: %s,\n",
+			p.value(k, keyTyped),
+			p.value(v.MapIndex(k), elemTyped),
+		))
+	}
+	buffer.WriteString("}")
+	return buffer.String()
+}
+
+func (p *goSyntaxPrinter) structValue(v reflect.Value) string {
+	buffer := bytes.NewBuffer(nil)
+	buffer.WriteString(v.Type().String() + "{")
+
+	empty := true
+	for i, n := 0, v.NumField(); i < n; i++ {
+		field := v.Field(i)
+		if field.IsZero() {
+			continue
+		}
+
+		if empty {
+			buffer.WriteString("\n")
+			empty = false
+		}
+		buffer.WriteString(fmt.Sprintf("%s: %s,\n",
+			v.Type().Field(i).Name,
+			p.value(field, field.Kind() == reflect.Interface),
+		))
+	}
+
+	buffer.WriteString("}")
+	return buffer.String()
+}
+
+// GoSyntax returns a gofmt-formatted Go literal of v which can be copied
+// into a test as the expected value. Map keys are sorted, zero struct
+// fields are omitted and types are qualified by package name.
+func GoSyntax(v interface{}) string {
+	p := &goSyntaxPrinter{visited: make(map[uintptr]bool)}
+	src := p.value(reflect.ValueOf(v), true)
+
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return src
+	}
+	return string(formatted)
+}
@@ -0,0 +1,191 @@
+package diff
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-repo/assert/diff/internal"
+)
+
+// Classes of graph nodes, each is drawn in its own color.
+const (
+	graphRemoved   = "removed"
+	graphAdded     = "added"
+	graphChanged   = "changed"
+	graphUnchanged = "unchanged"
+)
+
+// graphColors are the fill and line colors of the classes.
+var graphColors = map[string][2]string{
+	graphRemoved:   {"#ffebe9", "#cf222e"},
+	graphAdded:     {"#e6ffec", "#1a7f37"},
+	graphChanged:   {"#fff8c5", "#9a6700"},
+	graphUnchanged: {"#f6f8fa", "#8c959f"},
+}
+
+func graphClass(node *internal.Node) string {
+	switch {
+	case node.DiffXY == nil && node.DiffNum == 0:
+		return graphUnchanged
+	case node.DiffXY == nil:
+		return ""
+	case node.DiffXY.Y == nil:
+		return graphRemoved
+	case node.DiffXY.X == nil:
+		return graphAdded
+	}
+	return graphChanged
+}
+
+func graphXY(xy *internal.XY) string {
+	if xy.Type == "" {
+		return xy.Val
+	}
+	return xy.Type + "(" + xy.Val + ")"
+}
+
+func graphLabel(node *internal.Node) []string {
+	lines := []string{"key: " + node.Key}
+	if node.DiffXY == nil {
+		return append(lines,
+			"kind: "+node.Kind.String(),
+			"type: "+node.Type,
+			"diff_num: "+strconv.Itoa(node.DiffNum),
+		)
+	}
+
+	if node.DiffXY.X != nil {
+		lines = append(lines, "x: "+graphXY(node.DiffXY.X))
+	}
+	if node.DiffXY.Y != nil {
+		lines = append(lines, "y: "+graphXY(node.DiffXY.Y))
+	}
+	if node.DiffXY.Note != "" {
+		lines = append(lines, "note: "+node.DiffXY.Note)
+	}
+	return lines
+}
+
+// walkGraph calls node for every node of a tree in depth-first order and
+// edge for every link of a parent to a child. Nodes are named by their
+// order, so the names are the same for the same tree.
+func walkGraph(tree *internal.Node, o *internal.Options, node func(name string, n *internal.Node), edge func(parent, child string)) {
+	next := 0
+	var walk func(name string, n *internal.Node)
+	walk = func(name string, n *internal.Node) {
+		node(name, n)
+
+		for _, child := range n.Children {
+			if o.PruneUnchanged && child.DiffXY == nil && child.DiffNum == 0 {
+				continue
+			}
+
+			next++
+			childName := "n" + strconv.Itoa(next)
+			edge(name, childName)
+			walk(childName, child)
+		}
+	}
+	walk("n0", tree)
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteDOT writes a diff tree returned by Tree as a Graphviz DOT graph to w.
+// Removed, added and changed values are colored, unchanged levels are
+// grayed out or left out with PruneUnchanged.
+//
+//	dot -Tsvg tree.dot > tree.svg
+func WriteDOT(w io.Writer, tree *Node, opts ...Option) error {
+	p := newPrinter(w)
+	p.str("digraph DiffTree {\n")
+	p.str("node [shape=\"box\", fontname=\"menlo\"]\n")
+
+	walkGraph(tree, newOptions(opts),
+		func(name string, n *internal.Node) {
+			p.str(name)
+			p.str(" [label=\"")
+			for _, line := range graphLabel(n) {
+				p.str(dotEscaper.Replace(line))
+				p.str(`\l`)
+			}
+			p.str("\"")
+			if colors, ok := graphColors[graphClass(n)]; ok {
+				p.str(", style=\"filled\", fillcolor=\"")
+				p.str(colors[0])
+				p.str("\", color=\"")
+				p.str(colors[1])
+				p.str("\"")
+			}
+			p.str("]\n")
+		},
+		func(parent, child string) {
+			p.str(parent)
+			p.str(" -> ")
+			p.str(child)
+			p.str("\n")
+		},
+	)
+
+	p.str("}\n")
+	return p.flush()
+}
+
+var mermaidEscaper = strings.NewReplacer(
+	`"`, "#quot;", "<", "#lt;", ">", "#gt;", "#", "#35;", "\n", "<br/>",
+)
+
+// WriteMermaid writes a diff tree returned by Tree as a Mermaid flowchart
+// to w, colored like WriteDOT.
+func WriteMermaid(w io.Writer, tree *Node, opts ...Option) error {
+	p := newPrinter(w)
+	p.str("graph TD\n")
+
+	classes := map[string][]string{}
+	walkGraph(tree, newOptions(opts),
+		func(name string, n *internal.Node) {
+			p.str("    ")
+			p.str(name)
+			p.str("[\"")
+			for i, line := range graphLabel(n) {
+				if i > 0 {
+					p.str("<br/>")
+				}
+				p.str(mermaidEscaper.Replace(line))
+			}
+			p.str("\"]\n")
+
+			if class := graphClass(n); class != "" {
+				classes[class] = append(classes[class], name)
+			}
+		},
+		func(parent, child string) {
+			p.str("    ")
+			p.str(parent)
+			p.str(" --> ")
+			p.str(child)
+			p.str("\n")
+		},
+	)
+
+	for _, class := range []string{graphRemoved, graphAdded, graphChanged, graphUnchanged} {
+		p.str("    classDef ")
+		p.str(class)
+		p.str(" fill:")
+		p.str(graphColors[class][0])
+		p.str(",stroke:")
+		p.str(graphColors[class][1])
+		p.str("\n")
+
+		if names := classes[class]; len(names) > 0 {
+			p.str("    class ")
+			p.str(strings.Join(names, ","))
+			p.str(" ")
+			p.str(class)
+			p.str("\n")
+		}
+	}
+
+	return p.flush()
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 	"unsafe"
 )
 
@@ -36,6 +37,10 @@ func diffXYVal(v reflect.Value) string {
 		}
 	}
 
+	if v.Type() == timeType {
+		return timeOf(v).Format(time.RFC3339Nano)
+	}
+
 	if v.Kind() == reflect.String ||
 		v.Kind() == reflect.Interface {
 		return fmt.Sprintf("%#v", v)
@@ -62,6 +67,11 @@ func createChildNodes(current *Node, x, y reflect.Value, key string) {
 	})
 }
 
+func createChildNodesWithNote(current *Node, x, y reflect.Value, key, note string) {
+	createChildNodes(current, x, y, key)
+	current.Children[len(current.Children)-1].DiffXY.Note = note
+}
+
 func createChildNodeForX(current *Node, x reflect.Value, key string) {
 	current.Children = append(current.Children, &Node{
 		Key: key,
@@ -79,15 +89,25 @@ func createChildNodeForY(current *Node, y reflect.Value, key string) {
 	current.Children = append(current.Children, &Node{
 		Key: key,
 		DiffXY: &DiffXY{
-			Y: &XY{
-				Kind: y.Kind(),
-				Type: y.Type().String(),
-				Val:  diffXYVal(y),
-			},
+			Y: expectedXY(y),
 		},
 	})
 }
 
+// expectedXY is like the XY of an actual value but shows matchers as they
+// describe themselves.
+func expectedXY(y reflect.Value) *XY {
+	if m, ok := matcherOf(y); ok {
+		return &XY{Val: m.String()}
+	}
+
+	return &XY{
+		Kind: y.Kind(),
+		Type: y.Type().String(),
+		Val:  diffXYVal(y),
+	}
+}
+
 func diffNil(current *Node, x, y reflect.Value, key string) bool {
 	if x.IsNil() || y.IsNil() {
 		if x.IsNil() == y.IsNil() {
@@ -107,7 +127,7 @@ func ifFalseThenCreateChildNodes(b bool, current *Node, x, y reflect.Value, key
 	}
 }
 
-func cmpMap(curr *Node, x, y reflect.Value, key string, visited map[visit]bool) {
+func cmpMap(curr *Node, x, y reflect.Value, key string, s *state) {
 	if diffNil(curr, x, y, key) {
 		return
 	}
@@ -115,7 +135,7 @@ func cmpMap(curr *Node, x, y reflect.Value, key string, visited map[visit]bool)
 	yMap := map[interface{}]bool{}
 	newNode := createNewCurrentNode(curr, x, key)
 
-	for _, k := range x.MapKeys() {
+	for _, k := range SortedMapKeys(x) {
 		vx := x.MapIndex(k)
 		vy := y.MapIndex(k)
 		keyStr := fmt.Sprintf("%#v", k)
@@ -126,10 +146,10 @@ func cmpMap(curr *Node, x, y reflect.Value, key string, visited map[visit]bool)
 		}
 
 		yMap[newValue(k).Interface()] = true
-		deepDiff(newNode, vx, vy, keyStr, visited)
+		deepDiff(newNode, vx, vy, keyStr, s)
 	}
 
-	for _, k := range y.MapKeys() {
+	for _, k := range SortedMapKeys(y) {
 		if yMap[newValue(k).Interface()] {
 			continue
 		}
@@ -150,11 +170,7 @@ func diffIsValid(curr *Node, x, y reflect.Value, key string) bool {
 				X: &XY{
 					Val: "<nil>",
 				},
-				Y: &XY{
-					Kind: y.Kind(),
-					Type: y.Type().String(),
-					Val:  diffXYVal(y),
-				},
+				Y: expectedXY(y),
 			},
 		})
 		return true
@@ -237,11 +253,11 @@ func isReferenceCycle(v1, v2 reflect.Value, visited map[visit]bool) bool {
 	return false
 }
 
-func deepDiffSlice(curr *Node, x, y reflect.Value, visited map[visit]bool) {
+func deepDiffSlice(curr *Node, x, y reflect.Value, s *state) {
 	if x.Len() >= y.Len() {
 		var i int
 		for ; i < y.Len(); i++ {
-			deepDiff(curr, x.Index(i), y.Index(i), strconv.Itoa(i), visited)
+			deepDiff(curr, x.Index(i), y.Index(i), strconv.Itoa(i), s)
 		}
 
 		for ; i < x.Len(); i++ {
@@ -250,7 +266,7 @@ func deepDiffSlice(curr *Node, x, y reflect.Value, visited map[visit]bool) {
 	} else {
 		var i int
 		for ; i < x.Len(); i++ {
-			deepDiff(curr, x.Index(i), y.Index(i), strconv.Itoa(i), visited)
+			deepDiff(curr, x.Index(i), y.Index(i), strconv.Itoa(i), s)
 		}
 
 		for ; i < y.Len(); i++ {
@@ -260,7 +276,12 @@ func deepDiffSlice(curr *Node, x, y reflect.Value, visited map[visit]bool) {
 
 }
 
-func deepDiff(curr *Node, x, y reflect.Value, key string, visited map[visit]bool) {
+func deepDiff(curr *Node, x, y reflect.Value, key string, s *state) {
+	if m, ok := matcherOf(y); ok {
+		diffMatcher(curr, x, m, key)
+		return
+	}
+
 	if diffIsValid(curr, x, y, key) {
 		return
 	}
@@ -270,7 +291,12 @@ func deepDiff(curr *Node, x, y reflect.Value, key string, visited map[visit]bool
 		return
 	}
 
-	if isReferenceCycle(x, y, visited) {
+	if isReferenceCycle(x, y, s.visited) {
+		return
+	}
+
+	if x.Type() == timeType {
+		diffTime(curr, x, y, key, s.opts)
 		return
 	}
 
@@ -282,13 +308,13 @@ func deepDiff(curr *Node, x, y reflect.Value, key string, visited map[visit]bool
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 		ifFalseThenCreateChildNodes(x.Uint() == y.Uint(), curr, x, y, key)
 	case reflect.Float32, reflect.Float64:
-		ifFalseThenCreateChildNodes(x.Float() == y.Float(), curr, x, y, key)
+		diffFloat(curr, x, y, key, s.opts)
 	case reflect.Complex64, reflect.Complex128:
-		ifFalseThenCreateChildNodes(x.Complex() == y.Complex(), curr, x, y, key)
+		diffComplex(curr, x, y, key, s.opts)
 	case reflect.Array:
 		newNode := createNewCurrentNode(curr, x, key)
 		for i := 0; i < x.Len(); i++ {
-			deepDiff(newNode, x.Index(i), y.Index(i), strconv.Itoa(i), visited)
+			deepDiff(newNode, x.Index(i), y.Index(i), strconv.Itoa(i), s)
 		}
 	case reflect.Chan:
 		ifFalseThenCreateChildNodes(
@@ -302,27 +328,27 @@ func deepDiff(curr *Node, x, y reflect.Value, key string, visited map[visit]bool
 			return
 		}
 		newNode := createNewCurrentNode(curr, x, key)
-		deepDiff(newNode, x.Elem(), y.Elem(), key, visited)
+		deepDiff(newNode, x.Elem(), y.Elem(), key, s)
 	case reflect.Map:
-		cmpMap(curr, x, y, key, visited)
+		cmpMap(curr, x, y, key, s)
 	case reflect.Ptr:
 		if diffNil(curr, x, y, key) {
 			return
 		}
 		newNode := createNewCurrentNode(curr, x, key)
-		deepDiff(newNode, x.Elem(), y.Elem(), key, visited)
+		deepDiff(newNode, x.Elem(), y.Elem(), key, s)
 	case reflect.Slice:
 		if diffNil(curr, x, y, key) {
 			return
 		}
 		newNode := createNewCurrentNode(curr, x, key)
-		deepDiffSlice(newNode, x, y, visited)
+		deepDiffSlice(newNode, x, y, s)
 	case reflect.String:
 		ifFalseThenCreateChildNodes(x.String() == y.String(), curr, x, y, key)
 	case reflect.Struct:
 		newNode := createNewCurrentNode(curr, x, key)
 		for i, n := 0, x.NumField(); i < n; i++ {
-			deepDiff(newNode, x.Field(i), y.Field(i), x.Type().Field(i).Name, visited)
+			deepDiff(newNode, x.Field(i), y.Field(i), x.Type().Field(i).Name, s)
 		}
 	case reflect.UnsafePointer:
 		ifFalseThenCreateChildNodes(x.Pointer() == y.Pointer(), curr, x, y, key)
@@ -331,8 +357,16 @@ func deepDiff(curr *Node, x, y reflect.Value, key string, visited map[visit]bool
 	}
 }
 
-func Diff(x, y interface{}) *Node {
+type state struct {
+	opts    *Options
+	visited map[visit]bool
+}
+
+func Diff(x, y interface{}, opts *Options) *Node {
 	root := &Node{}
-	deepDiff(root, reflect.ValueOf(x), reflect.ValueOf(y), "", make(map[visit]bool))
+	deepDiff(root, reflect.ValueOf(x), reflect.ValueOf(y), "", &state{
+		opts:    opts,
+		visited: make(map[visit]bool),
+	})
 	return root
 }
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"reflect"
+	"strings"
+)
+
+// orderedBits maps a float to an unsigned integer such that the order of
+// floats is preserved and adjacent floats differ by one.
+func orderedBits(f float64, bitSize int) uint64 {
+	var b, signBit uint64
+	if bitSize == 32 {
+		b, signBit = uint64(math.Float32bits(float32(f))), 1<<31
+	} else {
+		b, signBit = math.Float64bits(f), 1<<63
+	}
+
+	if b&signBit != 0 {
+		return signBit - 1 - (b &^ signBit)
+	}
+	return b | signBit
+}
+
+func ulpDistance(x, y float64, bitSize int) uint64 {
+	bx := orderedBits(x, bitSize)
+	by := orderedBits(y, bitSize)
+	if bx > by {
+		return bx - by
+	}
+	return by - bx
+}
+
+type floatDistance struct {
+	delta    float64
+	relative float64
+	ulp      uint64
+}
+
+// equal reports whether the distance is within the tolerance of opts,
+// otherwise it also returns a note which compares them.
+func (d floatDistance) equal(opts *Options) (bool, string) {
+	if !opts.hasFloatTolerance() {
+		return false, ""
+	}
+
+	if (opts.FloatDeltaSet && d.delta <= opts.FloatDelta) ||
+		(opts.FloatEpsilonSet && d.relative <= opts.FloatEpsilon) ||
+		(opts.FloatULPSet && d.ulp <= opts.FloatULP) {
+		return true, ""
+	}
+
+	var notes []string
+	if opts.FloatDeltaSet {
+		notes = append(notes, fmt.Sprintf("delta %v > %v", d.delta, opts.FloatDelta))
+	}
+	if opts.FloatEpsilonSet {
+		notes = append(notes, fmt.Sprintf("relative %v > %v", d.relative, opts.FloatEpsilon))
+	}
+	if opts.FloatULPSet {
+		notes = append(notes, fmt.Sprintf("ulp %v > %v", d.ulp, opts.FloatULP))
+	}
+	return false, strings.Join(notes, ", ")
+}
+
+func relative(delta, x, y float64) float64 {
+	return delta / math.Max(math.Abs(x), math.Abs(y))
+}
+
+func floatEqual(x, y float64, bitSize int, opts *Options) (bool, string) {
+	if x == y {
+		return true, ""
+	}
+
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return opts.EquateNaNs && math.IsNaN(x) && math.IsNaN(y), ""
+	}
+
+	if math.IsInf(x, 0) || math.IsInf(y, 0) {
+		return false, ""
+	}
+
+	delta := math.Abs(x - y)
+	return floatDistance{
+		delta:    delta,
+		relative: relative(delta, x, y),
+		ulp:      ulpDistance(x, y, bitSize),
+	}.equal(opts)
+}
+
+func complexEqual(x, y complex128, bitSize int, opts *Options) (bool, string) {
+	if x == y {
+		return true, ""
+	}
+
+	if cmplx.IsNaN(x) || cmplx.IsNaN(y) {
+		return opts.EquateNaNs && cmplx.IsNaN(x) && cmplx.IsNaN(y), ""
+	}
+
+	if cmplx.IsInf(x) || cmplx.IsInf(y) {
+		return false, ""
+	}
+
+	delta := cmplx.Abs(x - y)
+	ulpReal := ulpDistance(real(x), real(y), bitSize/2)
+	ulpImag := ulpDistance(imag(x), imag(y), bitSize/2)
+	if ulpImag > ulpReal {
+		ulpReal = ulpImag
+	}
+
+	return floatDistance{
+		delta:    delta,
+		relative: relative(delta, cmplx.Abs(x), cmplx.Abs(y)),
+		ulp:      ulpReal,
+	}.equal(opts)
+}
+
+func diffFloat(curr *Node, x, y reflect.Value, key string, opts *Options) {
+	if ok, note := floatEqual(x.Float(), y.Float(), x.Type().Bits(), opts); !ok {
+		createChildNodesWithNote(curr, x, y, key, note)
+	}
+}
+
+func diffComplex(curr *Node, x, y reflect.Value, key string, opts *Options) {
+	if ok, note := complexEqual(x.Complex(), y.Complex(), x.Type().Bits(), opts); !ok {
+		createChildNodesWithNote(curr, x, y, key, note)
+	}
+}
@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// Matcher is implemented by placeholders in the expected value which match
+// the actual value by a rule instead of by equality.
+type Matcher interface {
+	// Match returns nil if actual matches, otherwise an error explaining why
+	// it does not. actual is nil for a nil interface.
+	Match(actual interface{}) error
+	// String describes the expected value, it is shown in the diff.
+	String() string
+}
+
+var matcherType = reflect.TypeOf((*Matcher)(nil)).Elem()
+
+// ValueInterface is like v.Interface() but also works for values of
+// unexported fields.
+func ValueInterface(v reflect.Value) interface{} {
+	if v.CanInterface() {
+		return v.Interface()
+	}
+
+	if v.Kind() == reflect.Ptr {
+		p := unsafe.Pointer(v.Pointer())
+		return reflect.NewAt(v.Type(), unsafe.Pointer(&p)).Elem().Interface()
+	}
+
+	return newValue(v).Interface()
+}
+
+func matcherOf(v reflect.Value) (Matcher, bool) {
+	if !v.IsValid() {
+		return nil, false
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil, false
+		}
+	case reflect.Ptr:
+		if v.IsNil() || !v.Type().Implements(matcherType) {
+			return nil, false
+		}
+	default:
+		if !v.Type().Implements(matcherType) {
+			return nil, false
+		}
+	}
+
+	m, ok := ValueInterface(v).(Matcher)
+	return m, ok
+}
+
+func diffMatcher(curr *Node, x reflect.Value, m Matcher, key string) {
+	if x.IsValid() && x.Kind() == reflect.Interface {
+		x = x.Elem()
+	}
+
+	var actual interface{}
+	xy := &XY{Val: "<nil>"}
+	if x.IsValid() {
+		actual = ValueInterface(x)
+		xy = &XY{
+			Kind: x.Kind(),
+			Type: x.Type().String(),
+			Val:  diffXYVal(x),
+		}
+	}
+
+	err := m.Match(actual)
+	if err == nil {
+		return
+	}
+
+	curr.Children = append(curr.Children, &Node{
+		Key: key,
+		DiffXY: &DiffXY{
+			X:    xy,
+			Y:    &XY{Val: m.String()},
+			Note: err.Error(),
+		},
+	})
+}
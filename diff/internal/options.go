@@ -0,0 +1,43 @@
+package internal
+
+import "time"
+
+// Options of how values are compared and printed.
+type Options struct {
+	// Floats and complex numbers are equal if they are within any of the
+	// following tolerances which is set, a zero tolerance can be set too.
+
+	// Maximum absolute difference.
+	FloatDelta    float64
+	FloatDeltaSet bool
+	// Maximum difference relative to the larger absolute value.
+	FloatEpsilon    float64
+	FloatEpsilonSet bool
+	// Maximum number of representable floats between them.
+	FloatULP    uint64
+	FloatULPSet bool
+	// NaN is equal to NaN.
+	EquateNaNs bool
+
+	// Times are equal if they are the same instant within TimeTolerance,
+	// regardless of location and monotonic clock reading.
+	CompareTimeInstants bool
+	TimeTolerance       time.Duration
+
+	// Levels deeper than MaxDepth are collapsed when printing a value,
+	// zero means no limit.
+	MaxDepth int
+	// Types are not printed.
+	HideTypes bool
+
+	// Levels without differences are left out of graphs.
+	PruneUnchanged bool
+
+	// A diff is printed in two columns of the total Width.
+	SideBySide bool
+	Width      int
+}
+
+func (o *Options) hasFloatTolerance() bool {
+	return o.FloatDeltaSet || o.FloatEpsilonSet || o.FloatULPSet
+}
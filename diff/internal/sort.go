@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+func lessKey(x, y reflect.Value) bool {
+	switch x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return x.Int() < y.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return x.Uint() < y.Uint()
+	case reflect.Float32, reflect.Float64:
+		return x.Float() < y.Float()
+	case reflect.String:
+		return x.String() < y.String()
+	}
+	return fmt.Sprintf("%#v", x) < fmt.Sprintf("%#v", y)
+}
+
+// SortedMapKeys returns keys of a map in a deterministic order.
+func SortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		x, y := keys[i], keys[j]
+		if x.Kind() == reflect.Interface && !x.IsNil() && !y.IsNil() {
+			x, y = x.Elem(), y.Elem()
+		}
+
+		if x.Kind() != y.Kind() {
+			return x.Kind() < y.Kind()
+		}
+		return lessKey(x, y)
+	})
+	return keys
+}
@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func timeOf(v reflect.Value) time.Time {
+	return ValueInterface(v).(time.Time)
+}
+
+// timeEqual compares two times, and if they are not equal, returns a note
+// which explains the difference.
+func timeEqual(x, y time.Time, opts *Options) (bool, string) {
+	// Strip monotonic clock readings.
+	skew := x.Round(0).Sub(y.Round(0))
+
+	if opts.CompareTimeInstants {
+		abs := skew
+		if abs < 0 {
+			abs = -abs
+		}
+
+		if abs <= opts.TimeTolerance {
+			return true, ""
+		}
+		if opts.TimeTolerance > 0 {
+			return false, fmt.Sprintf("skew %v > %v", skew, opts.TimeTolerance)
+		}
+		return false, fmt.Sprintf("skew %v", skew)
+	}
+
+	if reflect.DeepEqual(x, y) {
+		return true, ""
+	}
+
+	if skew == 0 {
+		if x.Location() != y.Location() {
+			return false, "same instant in different locations"
+		}
+		return false, "monotonic clock readings differ"
+	}
+	return false, fmt.Sprintf("skew %v", skew)
+}
+
+func diffTime(curr *Node, x, y reflect.Value, key string, opts *Options) {
+	if ok, note := timeEqual(timeOf(x), timeOf(y), opts); !ok {
+		createChildNodesWithNote(curr, x, y, key, note)
+	}
+}
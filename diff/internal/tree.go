@@ -22,4 +22,22 @@ type XY struct {
 type DiffXY struct {
 	X *XY
 	Y *XY
+	// Explains the difference, e.g. why a matcher did not match.
+	Note string
+}
+
+// HideTypes removes types from the tree.
+func HideTypes(node *Node) {
+	node.Type = ""
+	if node.DiffXY != nil {
+		for _, xy := range []*XY{node.DiffXY.X, node.DiffXY.Y} {
+			if xy != nil {
+				xy.Type = ""
+			}
+		}
+	}
+
+	for _, child := range node.Children {
+		HideTypes(child)
+	}
 }
@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+func createValueLeaf(current *Node, v reflect.Value, key string) {
+	xy := &XY{Val: "<nil>"}
+	if v.IsValid() {
+		xy = &XY{
+			Kind: v.Kind(),
+			Type: v.Type().String(),
+			Val:  diffXYVal(v),
+		}
+	}
+
+	current.Children = append(current.Children, &Node{
+		Key:    key,
+		DiffXY: &DiffXY{X: xy},
+	})
+}
+
+// valueTree builds the tree of a single value, leaves only have X. Pointers
+// on the path are remembered in visited to stop at reference cycles.
+func valueTree(curr *Node, v reflect.Value, key string, visited map[uintptr]bool) {
+	if !v.IsValid() || v.Type() == timeType {
+		createValueLeaf(curr, v, key)
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Array:
+		newNode := createNewCurrentNode(curr, v, key)
+		for i := 0; i < v.Len(); i++ {
+			valueTree(newNode, v.Index(i), strconv.Itoa(i), visited)
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			createValueLeaf(curr, v, key)
+			return
+		}
+		newNode := createNewCurrentNode(curr, v, key)
+		for i := 0; i < v.Len(); i++ {
+			valueTree(newNode, v.Index(i), strconv.Itoa(i), visited)
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			createValueLeaf(curr, v, key)
+			return
+		}
+		newNode := createNewCurrentNode(curr, v, key)
+		for _, k := range SortedMapKeys(v) {
+			valueTree(newNode, v.MapIndex(k), fmt.Sprintf("%#v", k), visited)
+		}
+	case reflect.Struct:
+		newNode := createNewCurrentNode(curr, v, key)
+		for i, n := 0, v.NumField(); i < n; i++ {
+			valueTree(newNode, v.Field(i), v.Type().Field(i).Name, visited)
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			createValueLeaf(curr, v, key)
+			return
+		}
+		if visited[v.Pointer()] {
+			createValueLeaf(curr, v, key)
+			curr.Children[len(curr.Children)-1].DiffXY.X.Val = "<cycle>"
+			return
+		}
+		visited[v.Pointer()] = true
+		newNode := createNewCurrentNode(curr, v, key)
+		valueTree(newNode, v.Elem(), key, visited)
+		delete(visited, v.Pointer())
+	case reflect.Interface:
+		if v.IsNil() {
+			createValueLeaf(curr, v, key)
+			return
+		}
+		newNode := createNewCurrentNode(curr, v, key)
+		valueTree(newNode, v.Elem(), key, visited)
+	default:
+		createValueLeaf(curr, v, key)
+	}
+}
+
+// Value returns the tree of a single value for printing it.
+func Value(v interface{}) *Node {
+	root := &Node{}
+	valueTree(root, reflect.ValueOf(v), "", make(map[uintptr]bool))
+	return root
+}
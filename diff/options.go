@@ -0,0 +1,97 @@
+package diff
+
+import (
+	"time"
+
+	"github.com/go-repo/assert/diff/internal"
+)
+
+// Option changes how values are compared.
+type Option func(*internal.Options)
+
+func newOptions(opts []Option) *internal.Options {
+	o := &internal.Options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// FloatDelta makes floats and complex numbers equal if their absolute
+// difference is at most delta.
+func FloatDelta(delta float64) Option {
+	return func(o *internal.Options) {
+		o.FloatDelta = delta
+		o.FloatDeltaSet = true
+	}
+}
+
+// FloatEpsilon makes floats and complex numbers equal if their difference
+// relative to the larger absolute value is at most epsilon.
+func FloatEpsilon(epsilon float64) Option {
+	return func(o *internal.Options) {
+		o.FloatEpsilon = epsilon
+		o.FloatEpsilonSet = true
+	}
+}
+
+// FloatULP makes floats and complex numbers equal if there are at most ulps
+// representable floats between them, complex numbers are compared by real
+// and imaginary parts.
+func FloatULP(ulps uint64) Option {
+	return func(o *internal.Options) {
+		o.FloatULP = ulps
+		o.FloatULPSet = true
+	}
+}
+
+// EquateNaNs makes NaN equal to NaN.
+func EquateNaNs() Option {
+	return func(o *internal.Options) {
+		o.EquateNaNs = true
+	}
+}
+
+// TimeTolerance makes times equal if they differ by at most tolerance,
+// regardless of location and monotonic clock reading. A zero tolerance
+// compares instants.
+func TimeTolerance(tolerance time.Duration) Option {
+	return func(o *internal.Options) {
+		o.CompareTimeInstants = true
+		o.TimeTolerance = tolerance
+	}
+}
+
+// MaxDepth collapses levels deeper than depth when printing a value by
+// Sprint or Fprint.
+func MaxDepth(depth int) Option {
+	return func(o *internal.Options) {
+		o.MaxDepth = depth
+	}
+}
+
+// HideTypes prints values without their types.
+func HideTypes() Option {
+	return func(o *internal.Options) {
+		o.HideTypes = true
+	}
+}
+
+// SideBySide prints a diff in two columns, actual on the left and expected
+// on the right, marking rows which differ by "|", rows only in actual by "<"
+// and rows only in expected by ">". Values longer than a column are wrapped.
+// A width of zero or less uses DefaultWidth.
+func SideBySide(width int) Option {
+	return func(o *internal.Options) {
+		o.SideBySide = true
+		o.Width = width
+	}
+}
+
+// PruneUnchanged leaves levels without differences out of the graphs
+// written by WriteDOT and WriteMermaid.
+func PruneUnchanged() Option {
+	return func(o *internal.Options) {
+		o.PruneUnchanged = true
+	}
+}
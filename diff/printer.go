@@ -0,0 +1,119 @@
+package diff
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/go-repo/assert/diff/internal"
+)
+
+// printer writes a tree piece by piece as it's walked, the first write
+// error is kept and stops later writes.
+type printer struct {
+	w interface {
+		WriteString(s string) (int, error)
+	}
+	buffered *bufio.Writer
+	err      error
+}
+
+func newPrinter(w io.Writer) *printer {
+	switch w := w.(type) {
+	case *strings.Builder:
+		return &printer{w: w}
+	case *bytes.Buffer:
+		return &printer{w: w}
+	}
+
+	buffered := bufio.NewWriter(w)
+	return &printer{w: buffered, buffered: buffered}
+}
+
+func (p *printer) str(s string) {
+	if p.err == nil {
+		_, p.err = p.w.WriteString(s)
+	}
+}
+
+func (p *printer) repeat(s string, count int) {
+	for i := 0; i < count; i++ {
+		p.str(s)
+	}
+}
+
+func (p *printer) flush() error {
+	if p.err == nil && p.buffered != nil {
+		p.err = p.buffered.Flush()
+	}
+	return p.err
+}
+
+// leaf writes a line like "-     key: *(int)(1) // note".
+func (p *printer) leaf(prefix string, deep, ptrDeep int, key string, xy *internal.XY, note string) {
+	p.str(prefix)
+	p.str(" ")
+	p.leafBody(deep, ptrDeep, key, xy, note)
+	p.str("\n")
+}
+
+func (p *printer) leafBody(deep, ptrDeep int, key string, xy *internal.XY, note string) {
+	p.repeat(indent, deep)
+	if key != "" {
+		p.str(key)
+		p.str(": ")
+	}
+
+	if xy.Type != "" {
+		if ptrDeep > 0 {
+			p.repeat("*", ptrDeep)
+			p.str("(")
+			p.str(xy.Type)
+			p.str(")(")
+		} else {
+			p.str(xy.Type)
+			p.str("(")
+		}
+		p.str(xy.Val)
+		p.str(")")
+	} else {
+		p.repeat("*", ptrDeep)
+		p.str(xy.Val)
+	}
+
+	if note != "" {
+		p.str(" // ")
+		p.str(note)
+	}
+}
+
+func (p *printer) levelBody(deep, ptrDeep int, key, typ string) {
+	p.repeat(indent, deep)
+	if key != "" {
+		p.str(key)
+		p.str(": ")
+	}
+	p.repeat("&", ptrDeep)
+	p.str(typ)
+}
+
+// level writes the first line of a level like "  key: &T{".
+func (p *printer) level(deep, ptrDeep int, key, typ string) {
+	p.str("  ")
+	p.levelBody(deep, ptrDeep, key, typ)
+	p.str("{\n")
+}
+
+// collapsedLevel writes a level deeper than the max depth like
+// "  key: &T{...}".
+func (p *printer) collapsedLevel(deep, ptrDeep int, key, typ string) {
+	p.str("  ")
+	p.levelBody(deep, ptrDeep, key, typ)
+	p.str("{...}\n")
+}
+
+func (p *printer) levelEnd(deep int) {
+	p.repeat(indent, deep)
+	p.str("  }\n")
+}
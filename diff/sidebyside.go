@@ -0,0 +1,144 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-repo/assert/diff/internal"
+)
+
+// DefaultWidth is the width of a side-by-side diff if SideBySide is given no
+// width.
+const DefaultWidth = 120
+
+const minColumnWidth = 10
+
+// Marks between the columns of a side-by-side diff.
+const (
+	markSame    = " "
+	markChanged = "|"
+	markXOnly   = "<"
+	markYOnly   = ">"
+)
+
+func columnWidth(width int) int {
+	if width <= 0 {
+		width = DefaultWidth
+	}
+	if w := (width - 3) / 2; w > minColumnWidth {
+		return w
+	}
+	return minColumnWidth
+}
+
+// cell returns the text written by write, to be placed in a column.
+func cell(write func(p *printer)) string {
+	builder := &strings.Builder{}
+	write(&printer{w: builder})
+	return builder.String()
+}
+
+// wrap splits s into lines of at most width runes.
+func wrap(s string, width int) []string {
+	var lines []string
+	for utf8.RuneCountInString(s) > width {
+		i, n := 0, 0
+		for n < width {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+			n++
+		}
+		lines = append(lines, s[:i])
+		s = s[i:]
+	}
+	return append(lines, s)
+}
+
+// row writes x and y in two columns separated by mark, a value longer than
+// a column is wrapped into more rows with the same mark.
+func (p *printer) row(width int, x, mark, y string) {
+	xLines, yLines := wrap(x, width), wrap(y, width)
+	for i := 0; i < len(xLines) || i < len(yLines); i++ {
+		var xLine, yLine string
+		if i < len(xLines) {
+			xLine = xLines[i]
+		}
+		if i < len(yLines) {
+			yLine = yLines[i]
+		}
+
+		p.str(xLine)
+		if mark != markSame || yLine != "" {
+			p.repeat(" ", width-utf8.RuneCountInString(xLine))
+			p.str(" ")
+			p.str(mark)
+			if yLine != "" {
+				p.str(" ")
+				p.str(yLine)
+			}
+		}
+		p.str("\n")
+	}
+}
+
+func (p *printer) sideBySideXY(node *internal.Node, deep, ptrDeep, width int) {
+	x, y := node.DiffXY.X, node.DiffXY.Y
+
+	var xCell, yCell string
+	if x != nil {
+		note := ""
+		if y == nil {
+			note = node.DiffXY.Note
+		}
+		xCell = cell(func(p *printer) { p.leafBody(deep, ptrDeep, node.Key, x, note) })
+	}
+	if y != nil {
+		yCell = cell(func(p *printer) { p.leafBody(deep, ptrDeep, node.Key, y, node.DiffXY.Note) })
+	}
+
+	mark := markChanged
+	if y == nil {
+		mark = markXOnly
+	} else if x == nil {
+		mark = markYOnly
+	}
+	p.row(width, xCell, mark, yCell)
+}
+
+func (p *printer) sideBySideTree(node *internal.Node, deep int, ptrDeep *int, width int) {
+	for _, child := range node.Children {
+		if child.DiffXY == nil && child.DiffNum == 0 {
+			continue
+		}
+
+		if child.DiffXY != nil {
+			p.sideBySideXY(child, deep, *ptrDeep, width)
+			*ptrDeep = 0
+			continue
+		}
+
+		switch child.Kind {
+		case reflect.Array, reflect.Map, reflect.Slice, reflect.Struct:
+			level := cell(func(p *printer) {
+				p.levelBody(deep, *ptrDeep, child.Key, child.Type)
+				p.str("{")
+			})
+			p.row(width, level, markSame, level)
+			*ptrDeep = 0
+
+			p.sideBySideTree(child, deep+1, ptrDeep, width)
+
+			end := strings.Repeat(indent, deep) + "}"
+			p.row(width, end, markSame, end)
+		case reflect.Ptr:
+			*ptrDeep = *ptrDeep + 1
+			p.sideBySideTree(child, deep, ptrDeep, width)
+		case reflect.Interface:
+			p.sideBySideTree(child, deep, ptrDeep, width)
+		default:
+			panic(fmt.Sprintf("%v kind should be handled as level", child.Kind.String()))
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/go-repo/assert/diff/internal"
+)
+
+func (p *printer) valueTree(node *internal.Node, deep int, ptrDeep *int, opts *internal.Options) {
+	for _, child := range node.Children {
+		if child.DiffXY != nil {
+			p.leaf(" ", deep, *ptrDeep, child.Key, child.DiffXY.X, "")
+			*ptrDeep = 0
+			continue
+		}
+
+		switch child.Kind {
+		case reflect.Array, reflect.Map, reflect.Slice, reflect.Struct:
+			if opts.MaxDepth > 0 && deep >= opts.MaxDepth {
+				p.collapsedLevel(deep, *ptrDeep, child.Key, child.Type)
+				*ptrDeep = 0
+				continue
+			}
+
+			p.level(deep, *ptrDeep, child.Key, child.Type)
+			*ptrDeep = 0
+
+			p.valueTree(child, deep+1, ptrDeep, opts)
+
+			p.levelEnd(deep)
+		case reflect.Ptr:
+			*ptrDeep = *ptrDeep + 1
+			p.valueTree(child, deep, ptrDeep, opts)
+		case reflect.Interface:
+			p.valueTree(child, deep, ptrDeep, opts)
+		default:
+			panic(fmt.Sprintf("%v kind should be handled as level", child.Kind.String()))
+		}
+	}
+}
+
+// Fprint formats a value in the same way as unchanged lines of a diff and
+// writes it to w. Map keys are sorted so the output is deterministic unless
+// the value contains pointers which can't be followed, like channels and
+// functions, reference cycles are printed as <cycle>. MaxDepth and
+// HideTypes change the output, other options are ignored.
+func Fprint(w io.Writer, v interface{}, opts ...Option) error {
+	o := newOptions(opts)
+	tree := internal.Value(v)
+	if o.HideTypes {
+		internal.HideTypes(tree)
+	}
+
+	p := newPrinter(w)
+	ptrDeep := 0
+	p.valueTree(tree, 0, &ptrDeep, o)
+
+	return p.flush()
+}
+
+// Sprint formats a value like Fprint and returns the string.
+func Sprint(v interface{}, opts ...Option) string {
+	builder := &strings.Builder{}
+	_ = Fprint(builder, v, opts...)
+	return builder.String()
+}
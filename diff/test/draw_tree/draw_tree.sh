@@ -1,3 +0,0 @@
-#!/usr/bin/env bash
-
-dot -Tpng tree.dot > tree.png
@@ -2,46 +2,256 @@ package errorassert
 
 import (
 	"testing"
+	"time"
 
-	"github.com/go-repo/assert/internal"
+	"github.com/go-repo/assert"
 )
 
-func Equal(t *testing.T, actual, expected interface{}) {
+// Option changes how an assertion compares values, options are created by
+// the functions of the assert package.
+type Option = assert.Option
+
+// Context of key/value pairs which are prefixed to the failure messages of
+// a test and its subtests until it's closed or the test ends.
+type Context = assert.Context
+
+// With adds key/value pairs to the failure messages of all assertions of
+// the test and its subtests, see assert.With.
+func With(t *testing.T, keysAndValues ...interface{}) *Context {
+	return assert.With(t, keysAndValues...)
+}
+
+func Equal(t *testing.T, actual, expected interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).Equal(actual, expected, opts...)
+}
+
+func NotEqual(t *testing.T, actual, expected interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).NotEqual(actual, expected, opts...)
+}
+
+func NoError(t *testing.T, err error, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).NoError(err, opts...)
+}
+
+func Nil(t *testing.T, actual interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).Nil(actual, opts...)
+}
+
+func NotNil(t *testing.T, actual interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).NotNil(actual, opts...)
+}
+
+func True(t *testing.T, value bool, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).True(value, opts...)
+}
+
+func False(t *testing.T, value bool, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).False(value, opts...)
+}
+
+func Condition(t *testing.T, condition func() bool, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).Condition(condition, opts...)
+}
+
+func InDelta(t *testing.T, actual, expected interface{}, delta float64, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).InDelta(actual, expected, delta, opts...)
+}
+
+func InEpsilon(t *testing.T, actual, expected interface{}, epsilon float64, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).InEpsilon(actual, expected, epsilon, opts...)
+}
+
+func WithinULP(t *testing.T, actual, expected interface{}, ulps uint64, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).WithinULP(actual, expected, ulps, opts...)
+}
+
+func WithinDuration(t *testing.T, actual, expected time.Time, delta time.Duration, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).WithinDuration(actual, expected, delta, opts...)
+}
+
+func TimeEqual(t *testing.T, actual, expected time.Time, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).TimeEqual(actual, expected, opts...)
+}
+
+func Greater(t *testing.T, actual, expected interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).Greater(actual, expected, opts...)
+}
+
+func GreaterOrEqual(t *testing.T, actual, expected interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).GreaterOrEqual(actual, expected, opts...)
+}
+
+func Less(t *testing.T, actual, expected interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).Less(actual, expected, opts...)
+}
+
+func LessOrEqual(t *testing.T, actual, expected interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).LessOrEqual(actual, expected, opts...)
+}
+
+func Between(t *testing.T, actual, min, max interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).Between(actual, min, max, opts...)
+}
+
+func IsSorted(t *testing.T, slice interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).IsSorted(slice, opts...)
+}
+
+func IsSortedBy(t *testing.T, slice interface{}, less func(i, j int) bool, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).IsSortedBy(slice, less, opts...)
+}
+
+func IsStrictlyIncreasing(t *testing.T, slice interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).IsStrictlyIncreasing(slice, opts...)
+}
+
+func IsType(t *testing.T, expectedType, actual interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).IsType(expectedType, actual, opts...)
+}
+
+func Implements(t *testing.T, interfaceObject, actual interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).Implements(interfaceObject, actual, opts...)
+}
+
+func AssignableTo(t *testing.T, expectedType, actual interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).AssignableTo(expectedType, actual, opts...)
+}
+
+func ConvertibleTo(t *testing.T, expectedType, actual interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).ConvertibleTo(expectedType, actual, opts...)
+}
+
+// As returns actual as type T, if it has another type, the test fails and
+// the zero value of T is returned.
+func As[T any](t *testing.T, actual interface{}, opts ...Option) T {
+	t.Helper()
+
+	v, _ := assert.AsOf[T](assert.New(t).OnFailure(assert.Fail), actual, opts...)
+	return v
+}
+
+// JSONEq decodes actual and expected JSON, which can be a string, []byte,
+// json.RawMessage or io.Reader, and checks they are semantically equal.
+func JSONEq(t *testing.T, actual, expected interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).JSONEq(actual, expected, opts...)
+}
+
+// Snapshot compares actual with the inline snapshot expected, bytes and
+// strings are compared as is, other values as formatted by diff.Sprint.
+// With UPDATE_SNAPSHOTS=1, or the -golden.update flag of the golden
+// package, expected is rewritten in the source file of the caller, so it
+// must be a string literal.
+func Snapshot(t *testing.T, actual interface{}, expected string, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).Snapshot(actual, expected, opts...)
+}
+
+// Receives receives a value from ch within a second and compares it to
+// expected like Equal.
+func Receives(t *testing.T, ch, expected interface{}, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).Receives(ch, expected, opts...)
+}
+
+func ReceivesWithin(t *testing.T, ch, expected interface{}, timeout time.Duration, opts ...Option) {
+	t.Helper()
+
+	assert.New(t).OnFailure(assert.Fail).ReceivesWithin(ch, expected, timeout, opts...)
+}
+
+// NeverReceives fails if ch has a value or is closed within the window.
+func NeverReceives(t *testing.T, ch interface{}, window time.Duration, opts ...Option) {
 	t.Helper()
 
-	if !internal.Equal(t, actual, expected) {
-		t.Fail()
-	}
+	assert.New(t).OnFailure(assert.Fail).NeverReceives(ch, window, opts...)
 }
 
-func NotEqual(t *testing.T, actual, expected interface{}) {
+// Closed waits up to a second for ch to be closed and fails if it had
+// values before.
+func Closed(t *testing.T, ch interface{}, opts ...Option) {
 	t.Helper()
 
-	if !internal.NotEqual(t, actual, expected) {
-		t.Fail()
-	}
+	assert.New(t).OnFailure(assert.Fail).Closed(ch, opts...)
 }
 
-func NoError(t *testing.T, err error) {
+// ReceivesSequence receives as many values as the slice expected has
+// within timeout and compares them to expected like Equal.
+func ReceivesSequence(t *testing.T, ch, expected interface{}, timeout time.Duration, opts ...Option) {
 	t.Helper()
 
-	if !internal.NoError(t, err) {
-		t.Fail()
-	}
+	assert.New(t).OnFailure(assert.Fail).ReceivesSequence(ch, expected, timeout, opts...)
 }
 
-func Nil(t *testing.T, actual interface{}) {
+// ReceivesUnordered is like ReceivesSequence but the values can be
+// received in any order.
+func ReceivesUnordered(t *testing.T, ch, expected interface{}, timeout time.Duration, opts ...Option) {
 	t.Helper()
 
-	if !internal.Nil(t, actual) {
-		t.Fail()
-	}
+	assert.New(t).OnFailure(assert.Fail).ReceivesUnordered(ch, expected, timeout, opts...)
 }
 
-func NotNil(t *testing.T, actual interface{}) {
+// NoGoroutineLeaks fails when the test ends if goroutines started by the
+// test goroutine, or by any goroutine since the call, didn't exit within a
+// second. Cleanups registered later run before the check. It can be called
+// at the start of the test or deferred:
+//
+//	defer errorassert.NoGoroutineLeaks(t)
+func NoGoroutineLeaks(t *testing.T, opts ...Option) {
 	t.Helper()
 
-	if !internal.NotNil(t, actual) {
-		t.Fail()
-	}
+	assert.New(t).OnFailure(assert.Fail).NoGoroutineLeaks(opts...)
 }
@@ -0,0 +1,154 @@
+// Package event decodes records of failed assertions from the output of go
+// test. Tests log a record in a single line for every failed assertion if
+// ASSERT_JSON_EVENTS=1 is set:
+//
+//	ASSERT_JSON_EVENTS=1 go test -json ./... | tool
+package event
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-repo/assert/internal"
+)
+
+const (
+	EnvKey = internal.EventEnvKey
+	Prefix = internal.EventPrefix
+)
+
+type (
+	Record = internal.Record
+	Change = internal.Change
+	Value  = internal.Value
+)
+
+// testEvent is an event of test2json.
+type testEvent struct {
+	Action  string
+	Package string
+	Test    string
+	Output  string
+}
+
+type outputKey struct {
+	pkg  string
+	test string
+}
+
+// Decoder reads records from the output of go test -json. Lines which are
+// not JSON are read as plain output, e.g. of go test -v.
+type Decoder struct {
+	scanner *bufio.Scanner
+	// Output of tests which is not a full line yet, test2json splits long
+	// lines into several events.
+	partial map[outputKey]string
+	records []Record
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	return &Decoder{
+		scanner: scanner,
+		partial: map[outputKey]string{},
+	}
+}
+
+func (d *Decoder) parseLine(pkg, line string) {
+	r, ok := internal.ParseEvent(line)
+	if !ok {
+		return
+	}
+	if r.Package == "" {
+		r.Package = pkg
+	}
+	d.records = append(d.records, r)
+}
+
+func (d *Decoder) output(key outputKey, output string) {
+	output = d.partial[key] + output
+	for {
+		i := strings.IndexByte(output, '\n')
+		if i < 0 {
+			break
+		}
+		d.parseLine(key.pkg, output[:i])
+		output = output[i+1:]
+	}
+
+	if output == "" {
+		delete(d.partial, key)
+	} else {
+		d.partial[key] = output
+	}
+}
+
+// flush parses the output which doesn't end with a newline.
+func (d *Decoder) flush() {
+	keys := make([]outputKey, 0, len(d.partial))
+	for key := range d.partial {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].pkg != keys[j].pkg {
+			return keys[i].pkg < keys[j].pkg
+		}
+		return keys[i].test < keys[j].test
+	})
+
+	for _, key := range keys {
+		d.parseLine(key.pkg, d.partial[key])
+		delete(d.partial, key)
+	}
+}
+
+// Decode returns the next record, or io.EOF if there are no more records.
+func (d *Decoder) Decode() (Record, error) {
+	for len(d.records) == 0 {
+		if !d.scanner.Scan() {
+			if err := d.scanner.Err(); err != nil {
+				return Record{}, err
+			}
+
+			d.flush()
+			if len(d.records) == 0 {
+				return Record{}, io.EOF
+			}
+			break
+		}
+
+		line := d.scanner.Text()
+		var e testEvent
+		if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &e) != nil {
+			d.output(outputKey{}, line+"\n")
+			continue
+		}
+		if e.Action == "output" {
+			d.output(outputKey{pkg: e.Package, test: e.Test}, e.Output)
+		}
+	}
+
+	r := d.records[0]
+	d.records = d.records[1:]
+	return r, nil
+}
+
+// DecodeAll returns all records of r.
+func DecodeAll(r io.Reader) ([]Record, error) {
+	var records []Record
+	d := NewDecoder(r)
+	for {
+		record, err := d.Decode()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,128 @@
+package event_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-repo/assert/errorassert"
+	"github.com/go-repo/assert/event"
+)
+
+const (
+	emitEnvKey = "TEST_EMIT_EVENTS"
+	// Prefix of the output of TestEmit with the line of its first assertion.
+	emitLinePrefix = "emit line: "
+)
+
+type item struct {
+	Name  string
+	Price int
+}
+
+// TestEmit fails on purpose when it's run by TestDecode.
+func TestEmit(t *testing.T) {
+	if os.Getenv(emitEnvKey) == "" {
+		return
+	}
+
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Printf("%s%v\n", emitLinePrefix, line+2)
+	errorassert.Equal(t, []item{{"a", 1}}, []item{{"a", 2}, {"b", 3}})
+	errorassert.NoError(t, os.ErrNotExist)
+}
+
+func TestDecode(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+
+	cmd := exec.Command("go", "test", "-json", "-count=1", "-run", "^TestEmit$")
+	for _, env := range os.Environ() {
+		if !strings.HasPrefix(env, "ASSERT_") {
+			cmd.Env = append(cmd.Env, env)
+		}
+	}
+	cmd.Env = append(cmd.Env, emitEnvKey+"=1", event.EnvKey+"=1")
+	output, _ := cmd.Output()
+
+	_, after, _ := strings.Cut(string(output), emitLinePrefix)
+	digits := strings.IndexFunc(after, func(r rune) bool { return r < '0' || r > '9' })
+	emitLine, err := strconv.Atoi(after[:digits])
+	if err != nil {
+		t.Fatalf("expected the line of TestEmit but got: %v\n%s", err, output)
+	}
+
+	records, err := event.DecodeAll(strings.NewReader(string(output)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file = filepath.ToSlash(file)
+	expected := []event.Record{
+		{
+			Package:   "github.com/go-repo/assert/event",
+			Test:      "TestEmit",
+			Assertion: "errorassert.Equal",
+			File:      file,
+			Line:      emitLine,
+			Message: `Actual (-) and expected (+) are not equal:
+  []event_test.item{
+      0: event_test.item{
+-         Price: int(1)
++         Price: int(2)
+      }
++     1: event_test.item({b 3})
+  }`,
+			Diff: []event.Change{
+				{
+					Path:     "[0].Price",
+					Actual:   &event.Value{Type: "int", Value: "1"},
+					Expected: &event.Value{Type: "int", Value: "2"},
+				},
+				{
+					Path:     "[1]",
+					Expected: &event.Value{Type: "event_test.item", Value: "{b 3}"},
+				},
+			},
+		},
+		{
+			Package:   "github.com/go-repo/assert/event",
+			Test:      "TestEmit",
+			Assertion: "errorassert.NoError",
+			File:      file,
+			Line:      emitLine + 1,
+			Message:   "Got unexpected error: file does not exist",
+		},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Fatalf("expected %+v but got %+v\n%s", expected, records, output)
+	}
+}
+
+func TestDecode__SplitLines(t *testing.T) {
+	stream := `{"Action":"run","Package":"p","Test":"TestA"}
+{"Action":"output","Package":"p","Test":"TestA","Output":"    a_test.go:3: ASSERT_FAILURE_JSON: {\"test\":\"TestA\","}
+{"Action":"output","Package":"p","Test":"TestB","Output":"    b_test.go:4: ASSERT_FAILURE_JSON: {\"test\":\"TestB\",\"message\":\"b\"}\n"}
+{"Action":"output","Package":"p","Test":"TestA","Output":"\"message\":\"a\"}\n"}
+    c_test.go:5: ASSERT_FAILURE_JSON: {"test":"TestC","message":"c"}
+{"Action":"fail","Package":"p","Test":"TestA"}
+`
+	records, err := event.DecodeAll(strings.NewReader(stream))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []event.Record{
+		{Package: "p", Test: "TestB", Message: "b"},
+		{Package: "p", Test: "TestA", Message: "a"},
+		{Test: "TestC", Message: "c"},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, records)
+	}
+}
@@ -0,0 +1,162 @@
+// Package golden compares values with snapshots stored in golden files
+// under testdata/golden, run tests with -golden.update or UPDATE_SNAPSHOTS=1
+// to rewrite them.
+//
+// To detect golden files which are no longer used, run tests via Run:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(golden.Run(m))
+//	}
+package golden
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-repo/assert/internal"
+)
+
+const (
+	UpdateEnvKey = internal.UpdateEnvKey
+	Dir          = "testdata/golden"
+	Ext          = ".golden"
+)
+
+var (
+	_ = flag.Bool(internal.UpdateFlag, false, "update golden files and inline snapshots")
+
+	usedMu sync.Mutex
+	used   = map[string]bool{}
+)
+
+// Path returns the golden file path of a snapshot in a test.
+func Path(t *testing.T, name string) string {
+	return filepath.Join(Dir, filepath.FromSlash(t.Name()), name+Ext)
+}
+
+func markUsed(path string) {
+	usedMu.Lock()
+	defer usedMu.Unlock()
+
+	used[path] = true
+}
+
+func write(path string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Equal compares actual with the snapshot name of the test, with
+// -golden.update the snapshot is written instead.
+func Equal(t *testing.T, name string, actual interface{}) {
+	t.Helper()
+
+	path := Path(t, name)
+	markUsed(path)
+	data := internal.SerializeSnapshot(actual)
+
+	if internal.Update() {
+		err := write(path, data)
+		if err != nil {
+			t.Fatalf("Can't update golden file %s: %v", path, err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Golden file %s doesn't exist, run tests with -golden.update to create it", path)
+	}
+	if err != nil {
+		t.Fatalf("Can't read golden file %s: %v", path, err)
+	}
+
+	if string(data) == string(expected) {
+		return
+	}
+
+	internal.Fail(t, nil, fmt.Sprintf("Actual (-) and golden file %s (+) are not equal:\n", path)+
+		internal.LineDiff(data, expected), nil)
+	t.FailNow()
+}
+
+func ranAllTests() bool {
+	for _, name := range []string{"test.run", "test.skip"} {
+		if f := flag.Lookup(name); f != nil && f.Value.String() != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// obsolete returns golden files which were not used by Equal.
+func obsolete() ([]string, error) {
+	usedMu.Lock()
+	defer usedMu.Unlock()
+
+	var paths []string
+	err := filepath.WalkDir(Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() && strings.HasSuffix(path, Ext) && !used[path] {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	sort.Strings(paths)
+	return paths, err
+}
+
+// Run runs the tests, and if all of them passed, fails on golden files
+// which were not used, with -golden.update they are removed instead. The
+// check is skipped if only a part of the tests was run.
+func Run(m *testing.M) int {
+	code := m.Run()
+	if code != 0 || !ranAllTests() {
+		return code
+	}
+
+	paths, err := obsolete()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "golden: can't find obsolete golden files: %v\n", err)
+		return 1
+	}
+
+	if len(paths) == 0 {
+		return code
+	}
+
+	if internal.Update() {
+		for _, path := range paths {
+			err := os.Remove(path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "golden: can't remove obsolete golden file: %v\n", err)
+				return 1
+			}
+		}
+		return code
+	}
+
+	fmt.Fprintf(os.Stderr, "golden: obsolete golden files, run tests with -golden.update to remove them:\n")
+	for _, path := range paths {
+		fmt.Fprintf(os.Stderr, "    %s\n", path)
+	}
+	return 1
+}
@@ -0,0 +1,96 @@
+package golden
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/go-repo/assert/internal"
+)
+
+// A test package can have its own -update flag.
+var update = flag.Bool("update", false, "update test data")
+
+func TestMain(m *testing.M) {
+	os.Exit(Run(m))
+}
+
+type response struct {
+	ID    int
+	Items map[string][]float64
+	Next  *response
+}
+
+func TestEqual(t *testing.T) {
+	Equal(t, "bytes", []byte{'a', 0, 'b'})
+	Equal(t, "text", "line 1\nline 2\n")
+	Equal(t, "value", &response{
+		ID:    1,
+		Items: map[string][]float64{"b": {2}, "a": {1, 1.5}},
+		Next:  &response{ID: 2},
+	})
+
+	t.Run("sub test", func(t *testing.T) {
+		Equal(t, "text", "sub")
+	})
+}
+
+func TestPath(t *testing.T) {
+	path := Path(t, "name")
+	if path != filepath.Join("testdata", "golden", "TestPath", "name.golden") {
+		t.Fatal(path)
+	}
+}
+
+func TestObsolete(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	paths, err := obsolete()
+	if err != nil || paths != nil {
+		t.Fatal(paths, err)
+	}
+
+	for _, path := range []string{
+		filepath.Join(Dir, "TestA", "b"+Ext),
+		filepath.Join(Dir, "TestA", "a"+Ext),
+		filepath.Join(Dir, "TestA", "other"),
+	} {
+		err = write(path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	markUsed(filepath.Join(Dir, "TestA", "b"+Ext))
+
+	paths, err = obsolete()
+	if err != nil || !reflect.DeepEqual(paths, []string{filepath.Join(Dir, "TestA", "a"+Ext)}) {
+		t.Fatal(paths, err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	if os.Getenv(UpdateEnvKey) != "" || *update {
+		t.Skip("snapshots are updated")
+	}
+
+	for _, name := range []string{"update", "golden.update"} {
+		if internal.Update() {
+			t.Fatalf("expected no update before -%s", name)
+		}
+		flag.Set(name, "true")
+		if !internal.Update() {
+			t.Errorf("expected update by -%s", name)
+		}
+		flag.Set(name, "false")
+	}
+}
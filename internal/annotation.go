@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	// AnnotationsEnvKey is the environment variable which selects the CI
+	// annotations of failed assertions, "github" prints workflow commands of
+	// GitHub Actions, "gitlab" or "gitlab:<path>" writes a code quality
+	// report of GitLab CI.
+	AnnotationsEnvKey = "ASSERT_ANNOTATIONS"
+	// GitLabReportFile is the default path of the code quality report, it's
+	// relative to the package directory of the tests. The package path is
+	// added to the file name, see PackageFile.
+	GitLabReportFile = "gl-code-quality-report.json"
+
+	// Changes listed in the summary of a failure.
+	maxSummaryChanges = 10
+)
+
+// annotationOutput is where workflow commands are printed, they must start
+// a line of the output, so they can't be logged by the test.
+var annotationOutput io.Writer = os.Stdout
+
+var gitLabReport struct {
+	sync.Mutex
+	issues []gitLabIssue
+}
+
+// summary returns the first line of the message of a failure followed by
+// its changed values or, if it has no diff, the rest of the message.
+func summary(f Failure) string {
+	if f.Tree == nil {
+		return f.Message
+	}
+
+	title, _, _ := strings.Cut(f.Message, "\n")
+	lines := []string{title}
+	all := changes(f.Tree, "", reflect.Invalid, nil)
+	for i, change := range all {
+		if i == maxSummaryChanges {
+			lines = append(lines, fmt.Sprintf("and %d more", len(all)-i))
+			break
+		}
+
+		path := change.Path
+		if path == "" {
+			path = "value"
+		}
+		actual, expected := "missing", "missing"
+		if change.Actual != nil {
+			actual = change.Actual.Value
+		}
+		if change.Expected != nil {
+			expected = change.Expected.Value
+		}
+		line := fmt.Sprintf("%s: %s != %s", path, actual, expected)
+		if change.Note != "" {
+			line = line + " (" + change.Note + ")"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// relPath returns path relative to the directory in the environment
+// variable dirEnvKey if it's set and contains path.
+func relPath(path, dirEnvKey string) string {
+	dir := os.Getenv(dirEnvKey)
+	if dir == "" {
+		return path
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+var (
+	githubDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// githubAnnotation returns the workflow command of GitHub Actions which
+// annotates the line of a failure.
+func githubAnnotation(f Failure) string {
+	title := f.Test
+	if f.Assertion != "" {
+		title = f.Assertion + " failed in " + f.Test
+	}
+
+	return fmt.Sprintf("::error file=%s,line=%d,title=%s::%s\n",
+		githubPropertyEscaper.Replace(relPath(f.File, "GITHUB_WORKSPACE")),
+		f.Line,
+		githubPropertyEscaper.Replace(title),
+		githubDataEscaper.Replace(summary(f)),
+	)
+}
+
+// gitLabIssue is an issue of the code quality report of GitLab CI.
+type gitLabIssue struct {
+	Description string `json:"description"`
+	CheckName   string `json:"check_name"`
+	Fingerprint string `json:"fingerprint"`
+	Severity    string `json:"severity"`
+	Location    struct {
+		Path  string `json:"path"`
+		Lines struct {
+			Begin int `json:"begin"`
+		} `json:"lines"`
+	} `json:"location"`
+}
+
+func newGitLabIssue(f Failure) gitLabIssue {
+	issue := gitLabIssue{
+		Description: f.Test + ": " + summary(f),
+		CheckName:   f.Assertion,
+		Severity:    "major",
+	}
+	issue.Location.Path = relPath(f.File, "CI_PROJECT_DIR")
+	issue.Location.Lines.Begin = f.Line
+
+	hash := sha1.Sum([]byte(fmt.Sprintf("%s\x00%s\x00%s\x00%d", f.Test, f.Assertion, issue.Location.Path, f.Line)))
+	issue.Fingerprint = hex.EncodeToString(hash[:])
+	return issue
+}
+
+func writeGitLabReport(path string, f Failure) error {
+	gitLabReport.Lock()
+	defer gitLabReport.Unlock()
+
+	gitLabReport.issues = append(gitLabReport.issues, newGitLabIssue(f))
+
+	data, err := json.MarshalIndent(gitLabReport.issues, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+func reportAnnotation(f Failure) error {
+	kind, path, _ := strings.Cut(os.Getenv(AnnotationsEnvKey), ":")
+	switch kind {
+	case "":
+		return nil
+	case "github":
+		_, err := io.WriteString(annotationOutput, githubAnnotation(f))
+		return err
+	case "gitlab":
+		if path == "" {
+			path = GitLabReportFile
+		}
+		return writeGitLabReport(PackageFile(path), f)
+	}
+	return fmt.Errorf("unknown %s %q", AnnotationsEnvKey, kind)
+}
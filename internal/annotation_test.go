@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/go-repo/assert/diff"
+)
+
+func annotatedFailure() Failure {
+	return Failure{
+		Test:      "TestA/50%",
+		Assertion: "assert.Equal",
+		File:      "/src/repo/pkg/a_test.go",
+		Line:      12,
+		Message:   "Actual (-) and expected (+) are not equal:\n  ...",
+		Tree: diff.Tree(
+			[]htmlItem{{Name: "a", Price: 1}},
+			[]htmlItem{{Name: "a,b", Price: 2}, {}},
+		),
+	}
+}
+
+func TestReportAnnotation__GitHub(t *testing.T) {
+	t.Setenv(AnnotationsEnvKey, "github")
+	t.Setenv("GITHUB_WORKSPACE", "/src/repo")
+
+	buffer := bytes.NewBuffer(nil)
+	annotationOutput = buffer
+	defer func() {
+		annotationOutput = os.Stdout
+	}()
+
+	err := reportAnnotation(annotatedFailure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = reportAnnotation(Failure{Test: "TestB", File: "/other/b_test.go", Line: 3, Message: "a\r\nb"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "::error file=pkg/a_test.go,line=12,title=assert.Equal failed in TestA/50%25::" +
+		"Actual (-) and expected (+) are not equal:%0A" +
+		`[0].Name: "a" != "a,b"%0A` +
+		"[0].Price: 1 != 2%0A" +
+		"[1]: missing != { 0}\n" +
+		"::error file=/other/b_test.go,line=3,title=TestB::a%0D%0Ab\n"
+	if buffer.String() != expected {
+		t.Fatalf("expected:\n%s\nbut got:\n%s", expected, buffer.String())
+	}
+}
+
+func TestReportAnnotation__GitLab(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	t.Setenv(AnnotationsEnvKey, "gitlab:"+path)
+	t.Setenv("CI_PROJECT_DIR", "/src/repo")
+	defer func() {
+		gitLabReport.issues = nil
+	}()
+
+	for i := 0; i < 2; i++ {
+		err := reportAnnotation(annotatedFailure())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(path), "report.github.com_go-repo_assert_internal.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var issues []map[string]interface{}
+	err = json.Unmarshal(data, &issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues but got: %s", data)
+	}
+	expected := map[string]interface{}{
+		"description": "TestA/50%: Actual (-) and expected (+) are not equal:\n" +
+			"[0].Name: \"a\" != \"a,b\"\n[0].Price: 1 != 2\n[1]: missing != { 0}",
+		"check_name":  "assert.Equal",
+		"fingerprint": issues[0]["fingerprint"],
+		"severity":    "major",
+		"location": map[string]interface{}{
+			"path":  "pkg/a_test.go",
+			"lines": map[string]interface{}{"begin": float64(12)},
+		},
+	}
+	if !reflect.DeepEqual(issues[0], expected) || !reflect.DeepEqual(issues[1], expected) {
+		t.Fatalf("expected %v but got: %s", expected, data)
+	}
+	if len(issues[0]["fingerprint"].(string)) != 40 {
+		t.Fatalf("expected sha1 fingerprint but got: %v", issues[0]["fingerprint"])
+	}
+}
+
+func TestReportAnnotation__Unknown(t *testing.T) {
+	t.Setenv(AnnotationsEnvKey, "jenkins")
+
+	err := reportAnnotation(annotatedFailure())
+	if err == nil || err.Error() != `unknown ASSERT_ANNOTATIONS "jenkins"` {
+		t.Fatalf("expected error but got: %v", err)
+	}
+}
@@ -1,31 +1,50 @@
 package internal
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/go-repo/assert/diff"
 )
 
-func Equal(t *testing.T, actual, expected interface{}) bool {
+// equal returns the diff tree of actual and expected and whether they are
+// equal, the tree is nil if they are deeply equal. Unlike reflect.DeepEqual
+// it takes matchers in expected into account.
+func equal(actual, expected interface{}, opts []diff.Option) (*diff.Node, bool) {
+	if reflect.DeepEqual(actual, expected) {
+		return nil, true
+	}
+
+	tree := diff.Tree(actual, expected, opts...)
+	return tree, tree.DiffNum == 0
+}
+
+func Equal(t *testing.T, actual, expected interface{}, opts ...Option) bool {
 	t.Helper()
 
-	if reflect.DeepEqual(actual, expected) {
+	c := NewConfig(opts)
+	tree, ok := equal(actual, expected, c.DiffOptions)
+	if ok {
 		return true
 	}
 
-	t.Log("Actual (-) and expected (+) are not equal:\n" + diff.Diff(actual, expected))
+	msg := "Actual (-) and expected (+) are not equal:\n" + diff.DiffTree(tree, c.DiffOptions...)
+	if c.GoSyntax {
+		msg = msg + "\nActual in Go syntax:\n" + diff.GoSyntax(actual)
+	}
+	Fail(t, opts, msg, tree)
 	return false
 }
 
-func NotEqual(t *testing.T, actual, expected interface{}) bool {
+func NotEqual(t *testing.T, actual, expected interface{}, opts ...Option) bool {
 	t.Helper()
 
-	if !reflect.DeepEqual(actual, expected) {
+	if _, ok := equal(actual, expected, NewConfig(opts).DiffOptions); !ok {
 		return true
 	}
 
-	t.Logf("Actual and expected are equal: %#v\n", actual)
+	Fail(t, opts, fmt.Sprintf("Actual and expected are equal: %#v", actual), nil)
 	return false
 }
 
@@ -49,35 +68,35 @@ func isNil(i interface{}) bool {
 	return false
 }
 
-func NoError(t *testing.T, err error) bool {
+func NoError(t *testing.T, err error, opts ...Option) bool {
 	t.Helper()
 
 	if isNil(err) {
 		return true
 	}
 
-	t.Logf("Got unexpected error: %v\n", err)
+	Fail(t, opts, fmt.Sprintf("Got unexpected error: %v", err), nil)
 	return false
 }
 
-func Nil(t *testing.T, actual interface{}) bool {
+func Nil(t *testing.T, actual interface{}, opts ...Option) bool {
 	t.Helper()
 
 	if isNil(actual) {
 		return true
 	}
 
-	t.Logf("Expected nil but got: %#v\n", actual)
+	Fail(t, opts, fmt.Sprintf("Expected nil but got: %#v", actual), nil)
 	return false
 }
 
-func NotNil(t *testing.T, actual interface{}) bool {
+func NotNil(t *testing.T, actual interface{}, opts ...Option) bool {
 	t.Helper()
 
 	if !isNil(actual) {
 		return true
 	}
 
-	t.Logf("Expected not nil but got nil: %#v\n", actual)
+	Fail(t, opts, fmt.Sprintf("Expected not nil but got nil: %#v", actual), nil)
 	return false
 }
@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+const modulePath = "github.com/go-repo/assert"
+
+// FuncPackage returns the package path of a function name from
+// runtime.Frame, e.g. "github.com/go-repo/assert/internal" of
+// "github.com/go-repo/assert/internal.Caller".
+func FuncPackage(fn string) string {
+	slash := strings.LastIndexByte(fn, '/')
+	if dot := strings.IndexByte(fn[slash+1:], '.'); dot >= 0 {
+		return fn[:slash+1+dot]
+	}
+	return fn
+}
+
+func isLibraryPackage(pkg string) bool {
+	return (pkg == modulePath || strings.HasPrefix(pkg, modulePath+"/")) &&
+		!strings.HasSuffix(pkg, "_test")
+}
+
+// funcName returns the short name of a function name from runtime.Frame,
+// e.g. "assert.Equal" of "github.com/go-repo/assert.Equal" and "assert.As"
+// of "github.com/go-repo/assert.As[...]".
+func funcName(fn string) string {
+	fn = fn[strings.LastIndexByte(fn, '/')+1:]
+	if i := strings.IndexByte(fn, '['); i >= 0 {
+		fn = fn[:i]
+	}
+	return fn
+}
+
+// caller returns the frame which called into the packages of this module
+// and the function it called.
+func caller() (_ runtime.Frame, fn string, _ bool) {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !isLibraryPackage(FuncPackage(frame.Function)) {
+			return frame, fn, true
+		}
+		fn = frame.Function
+		if !more {
+			return runtime.Frame{}, "", false
+		}
+	}
+}
+
+// Caller returns the frame which called into the packages of this module,
+// for a direct call of an assertion it's the frame reported by t.Helper().
+func Caller() (runtime.Frame, bool) {
+	frame, _, ok := caller()
+	return frame, ok
+}
+
+// Stack returns the frames of the calling goroutine outside the packages of
+// this module and the runtime, formatted like runtime.Stack.
+func Stack() string {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var b strings.Builder
+	for {
+		frame, more := frames.Next()
+		pkg := FuncPackage(frame.Function)
+		if !isLibraryPackage(pkg) && pkg != "runtime" {
+			fmt.Fprintf(&b, "%s()\n\t%s:%v\n", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			return b.String()
+		}
+	}
+}
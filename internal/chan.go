@@ -0,0 +1,266 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-repo/assert/diff"
+)
+
+// ReceiveTimeout is the time Receives and Closed wait for the channel.
+const ReceiveTimeout = time.Second
+
+func chanValue(t *testing.T, ch interface{}, opts []Option) (reflect.Value, bool) {
+	t.Helper()
+
+	v := reflect.ValueOf(ch)
+	if v.Kind() != reflect.Chan || v.Type().ChanDir()&reflect.RecvDir == 0 {
+		Fail(t, opts, fmt.Sprintf("Expected receive channel but got: %#v", ch), nil)
+		return v, false
+	}
+	return v, true
+}
+
+// receive receives a value from ch unless timeout receives first, ok is
+// false if ch is closed.
+func receive(ch reflect.Value, timeout <-chan time.Time) (v reflect.Value, ok, timedOut bool) {
+	chosen, v, ok := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: ch},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timeout)},
+	})
+	if chosen == 1 {
+		return reflect.Value{}, false, true
+	}
+	return v, ok, false
+}
+
+func Receives(t *testing.T, ch, expected interface{}, opts ...Option) bool {
+	t.Helper()
+
+	return ReceivesWithin(t, ch, expected, ReceiveTimeout, opts...)
+}
+
+func ReceivesWithin(t *testing.T, ch, expected interface{}, timeout time.Duration, opts ...Option) bool {
+	t.Helper()
+
+	v, ok := chanValue(t, ch, opts)
+	if !ok {
+		return false
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	received, ok, timedOut := receive(v, timer.C)
+	if timedOut {
+		Fail(t, opts, fmt.Sprintf("Received nothing within %v, expected: %#v", timeout, expected), nil)
+		return false
+	}
+	if !ok {
+		Fail(t, opts, fmt.Sprintf("Channel is closed, expected: %#v", expected), nil)
+		return false
+	}
+
+	actual := received.Interface()
+	diffOpts := NewConfig(opts).DiffOptions
+	tree, ok := equal(actual, expected, diffOpts)
+	if ok {
+		return true
+	}
+
+	Fail(t, opts, "Received (-) and expected (+) are not equal:\n"+diff.DiffTree(tree, diffOpts...), tree)
+	return false
+}
+
+func NeverReceives(t *testing.T, ch interface{}, window time.Duration, opts ...Option) bool {
+	t.Helper()
+
+	v, ok := chanValue(t, ch, opts)
+	if !ok {
+		return false
+	}
+
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+
+	received, ok, timedOut := receive(v, timer.C)
+	if timedOut {
+		return true
+	}
+	if !ok {
+		Fail(t, opts, fmt.Sprintf("Expected no value within %v but channel is closed", window), nil)
+		return false
+	}
+
+	Fail(t, opts, fmt.Sprintf("Expected no value within %v but received: %#v", window, received.Interface()), nil)
+	return false
+}
+
+func Closed(t *testing.T, ch interface{}, opts ...Option) bool {
+	t.Helper()
+
+	v, ok := chanValue(t, ch, opts)
+	if !ok {
+		return false
+	}
+
+	timer := time.NewTimer(ReceiveTimeout)
+	defer timer.Stop()
+
+	values := reflect.MakeSlice(reflect.SliceOf(v.Type().Elem()), 0, v.Len())
+	for {
+		received, ok, timedOut := receive(v, timer.C)
+		if timedOut {
+			msg := fmt.Sprintf("Expected closed channel but it's open after %v", ReceiveTimeout)
+			if values.Len() > 0 {
+				msg += fmt.Sprintf(", received: %#v", values.Interface())
+			}
+			Fail(t, opts, msg, nil)
+			return false
+		}
+		if !ok {
+			break
+		}
+		values = reflect.Append(values, received)
+	}
+
+	if values.Len() == 0 {
+		return true
+	}
+
+	Fail(t, opts, fmt.Sprintf("Expected drained channel but received: %#v", values.Interface()), nil)
+	return false
+}
+
+// receiveSequence receives as many values as expected has within timeout,
+// the values are in a slice of the type of expected if they are assignable
+// to its elements. It returns why it received less values.
+func receiveSequence(ch, expected reflect.Value, timeout time.Duration) (reflect.Value, string) {
+	sliceType := reflect.SliceOf(ch.Type().Elem())
+	if ch.Type().Elem().AssignableTo(expected.Type().Elem()) {
+		sliceType = reflect.SliceOf(expected.Type().Elem())
+	}
+	values := reflect.MakeSlice(sliceType, 0, expected.Len())
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for values.Len() < expected.Len() {
+		received, ok, timedOut := receive(ch, timer.C)
+		if timedOut {
+			return values, fmt.Sprintf("received nothing more within %v", timeout)
+		}
+		if !ok {
+			return values, "channel is closed"
+		}
+		values = reflect.Append(values, received)
+	}
+	return values, ""
+}
+
+// expectedSlice converts an array to a slice so it can be compared with the
+// received values.
+func expectedSlice(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Slice {
+		return v
+	}
+	slice := reflect.MakeSlice(reflect.SliceOf(v.Type().Elem()), v.Len(), v.Len())
+	reflect.Copy(slice, v)
+	return slice
+}
+
+func failSequence(t *testing.T, received, expected reflect.Value, reason string, opts []Option) bool {
+	t.Helper()
+
+	diffOpts := NewConfig(opts).DiffOptions
+	tree, ok := equal(received.Interface(), expected.Interface(), diffOpts)
+	if ok && reason == "" {
+		return true
+	}
+
+	msg := "Received (-) and expected (+) sequences are not equal"
+	if reason != "" {
+		msg += ", " + reason
+	}
+	Fail(t, opts, msg+":\n"+diff.DiffTree(tree, diffOpts...), tree)
+	return false
+}
+
+func ReceivesSequence(t *testing.T, ch, expected interface{}, timeout time.Duration, opts ...Option) bool {
+	t.Helper()
+
+	v, ok := chanValue(t, ch, opts)
+	if !ok {
+		return false
+	}
+	e, ok := sliceValue(t, expected, opts)
+	if !ok {
+		return false
+	}
+	e = expectedSlice(e)
+
+	received, reason := receiveSequence(v, e, timeout)
+	return failSequence(t, received, e, reason, opts)
+}
+
+func ReceivesUnordered(t *testing.T, ch, expected interface{}, timeout time.Duration, opts ...Option) bool {
+	t.Helper()
+
+	v, ok := chanValue(t, ch, opts)
+	if !ok {
+		return false
+	}
+	e, ok := sliceValue(t, expected, opts)
+	if !ok {
+		return false
+	}
+	e = expectedSlice(e)
+
+	received, reason := receiveSequence(v, e, timeout)
+	return failSequence(t, matchOrder(received, e, NewConfig(opts).DiffOptions), e, reason, opts)
+}
+
+// matchOrder reorders the received values to the order of the expected
+// values they are equal to, the other received values take the places of
+// expected values without an equal value in the order they were received.
+func matchOrder(received, expected reflect.Value, opts []diff.Option) reflect.Value {
+	used := make([]bool, received.Len())
+	matches := make([]int, expected.Len())
+	for i := range matches {
+		matches[i] = -1
+		for j := 0; j < received.Len(); j++ {
+			if used[j] {
+				continue
+			}
+			if _, ok := equal(received.Index(j).Interface(), expected.Index(i).Interface(), opts); ok {
+				matches[i] = j
+				used[j] = true
+				break
+			}
+		}
+	}
+
+	var unmatched []int
+	for j := range used {
+		if !used[j] {
+			unmatched = append(unmatched, j)
+		}
+	}
+
+	ordered := reflect.MakeSlice(received.Type(), 0, received.Len())
+	for _, j := range matches {
+		if j < 0 {
+			if len(unmatched) == 0 {
+				continue
+			}
+			j, unmatched = unmatched[0], unmatched[1:]
+		}
+		ordered = reflect.Append(ordered, received.Index(j))
+	}
+	for _, j := range unmatched {
+		ordered = reflect.Append(ordered, received.Index(j))
+	}
+	return ordered
+}
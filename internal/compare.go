@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"reflect"
+	"time"
+)
+
+func isInt(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUint(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isFloat(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+// ToFloat converts a value of any integer or float kind to float64.
+func ToFloat(i interface{}) (float64, bool) {
+	if i == nil {
+		return 0, false
+	}
+
+	v := reflect.ValueOf(i)
+	switch k := v.Kind(); {
+	case isInt(k):
+		return float64(v.Int()), true
+	case isUint(k):
+		return float64(v.Uint()), true
+	case isFloat(k):
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+func sign(b bool) int {
+	if b {
+		return -1
+	}
+	return 1
+}
+
+func cmpInt64(x, y int64) int {
+	if x == y {
+		return 0
+	}
+	return sign(x < y)
+}
+
+func cmpUint64(x, y uint64) int {
+	if x == y {
+		return 0
+	}
+	return sign(x < y)
+}
+
+func cmpFloat64(x, y float64) int {
+	if x == y {
+		return 0
+	}
+	return sign(x < y)
+}
+
+// Compare returns -1, 0 or +1 depending on whether x is less than, equal to
+// or greater than y. Numbers of different kinds can be compared with each
+// other, strings only with strings and times only with times. ok is false
+// if x and y are not comparable or one of them is NaN.
+func Compare(x, y interface{}) (_ int, ok bool) {
+	if x == nil || y == nil {
+		return 0, false
+	}
+
+	if tx, isTime := x.(time.Time); isTime {
+		ty, isTime := y.(time.Time)
+		if !isTime {
+			return 0, false
+		}
+		if tx.Equal(ty) {
+			return 0, true
+		}
+		return sign(tx.Before(ty)), true
+	}
+
+	vx := reflect.ValueOf(x)
+	vy := reflect.ValueOf(y)
+	kx := vx.Kind()
+	ky := vy.Kind()
+
+	switch {
+	case kx == reflect.String && ky == reflect.String:
+		if vx.String() == vy.String() {
+			return 0, true
+		}
+		return sign(vx.String() < vy.String()), true
+	case isInt(kx) && isInt(ky):
+		return cmpInt64(vx.Int(), vy.Int()), true
+	case isUint(kx) && isUint(ky):
+		return cmpUint64(vx.Uint(), vy.Uint()), true
+	case isInt(kx) && isUint(ky):
+		if vx.Int() < 0 {
+			return -1, true
+		}
+		return cmpUint64(uint64(vx.Int()), vy.Uint()), true
+	case isUint(kx) && isInt(ky):
+		if vy.Int() < 0 {
+			return 1, true
+		}
+		return cmpUint64(vx.Uint(), uint64(vy.Int())), true
+	}
+
+	fx, okx := ToFloat(x)
+	fy, oky := ToFloat(y)
+	if !okx || !oky || fx != fx || fy != fy {
+		return 0, false
+	}
+	return cmpFloat64(fx, fy), true
+}
@@ -0,0 +1,284 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func True(t *testing.T, value bool, opts ...Option) bool {
+	t.Helper()
+
+	if value {
+		return true
+	}
+
+	failCondition(t, "Expected true", opts)
+	return false
+}
+
+func False(t *testing.T, value bool, opts ...Option) bool {
+	t.Helper()
+
+	if !value {
+		return true
+	}
+
+	failCondition(t, "Expected false", opts)
+	return false
+}
+
+func Condition(t *testing.T, condition func() bool, opts ...Option) bool {
+	t.Helper()
+
+	if condition() {
+		return true
+	}
+
+	failCondition(t, "Expected condition to be true", opts)
+	return false
+}
+
+// failCondition fails with the expression of the condition argument of the
+// assertion and the values of its sub-expressions, or without them if the
+// source isn't available.
+func failCondition(t *testing.T, msg string, opts []Option) {
+	t.Helper()
+
+	frame, fn, ok := caller()
+	if !ok {
+		Fail(t, opts, msg, nil)
+		return
+	}
+
+	s, call, err := assertionCall(frame.File, frame.Line, funcName(fn))
+	if err != nil {
+		Fail(t, opts, msg, nil)
+		return
+	}
+	args := s.assertionArgs(call)
+	if len(args) == 0 {
+		Fail(t, opts, msg, nil)
+		return
+	}
+
+	Fail(t, opts, msg+": "+sprintCondition(s, conditionExpr(args[0]), NewConfig(opts).Vars), nil)
+}
+
+// conditionExpr returns the returned expression of a function literal with
+// a single statement, or the expression itself.
+func conditionExpr(expr ast.Expr) ast.Expr {
+	lit, ok := expr.(*ast.FuncLit)
+	if !ok || len(lit.Body.List) != 1 {
+		return expr
+	}
+	ret, ok := lit.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return expr
+	}
+	return ret.Results[0]
+}
+
+// sprintCondition returns the source text of a condition followed by the
+// values of its sub-expressions which can be evaluated from vars.
+func sprintCondition(s *source, expr ast.Expr, vars map[string]interface{}) string {
+	var b strings.Builder
+	b.WriteString(s.text(expr))
+
+	e := evaluator{vars: vars}
+	seen := map[string]bool{}
+	e.walk(expr, func(sub ast.Expr, v reflect.Value) {
+		text := s.text(sub)
+		if seen[text] {
+			return
+		}
+		seen[text] = true
+		fmt.Fprintf(&b, "\n  %s = %#v", text, v)
+	})
+	return b.String()
+}
+
+// evaluator evaluates identifiers, selectors of fields, indexes,
+// dereferences and calls of len and cap, which have no side effects.
+type evaluator struct {
+	vars map[string]interface{}
+}
+
+// walk calls fn with the identifier, selector, index and call
+// sub-expressions of expr which can be evaluated, parents before children.
+func (e evaluator) walk(expr ast.Expr, fn func(ast.Expr, reflect.Value)) {
+	switch x := expr.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.IndexExpr, *ast.CallExpr:
+		if v, ok := e.eval(x); ok {
+			fn(x, v)
+		}
+	}
+
+	switch x := expr.(type) {
+	case *ast.ParenExpr:
+		e.walk(x.X, fn)
+	case *ast.UnaryExpr:
+		e.walk(x.X, fn)
+	case *ast.StarExpr:
+		e.walk(x.X, fn)
+	case *ast.BinaryExpr:
+		e.walk(x.X, fn)
+		e.walk(x.Y, fn)
+	case *ast.SelectorExpr:
+		e.walk(x.X, fn)
+	case *ast.IndexExpr:
+		e.walk(x.X, fn)
+		e.walk(x.Index, fn)
+	case *ast.CallExpr:
+		for _, arg := range x.Args {
+			e.walk(arg, fn)
+		}
+	}
+}
+
+func (e evaluator) eval(expr ast.Expr) (reflect.Value, bool) {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		i, ok := e.vars[x.Name]
+		if !ok {
+			return reflect.Value{}, false
+		}
+		v := reflect.ValueOf(i)
+		return v, v.IsValid()
+
+	case *ast.BasicLit:
+		return evalLiteral(x)
+
+	case *ast.ParenExpr:
+		return e.eval(x.X)
+
+	case *ast.StarExpr:
+		v, ok := e.eval(x.X)
+		if !ok || v.Kind() != reflect.Ptr || v.IsNil() {
+			return reflect.Value{}, false
+		}
+		return v.Elem(), true
+
+	case *ast.SelectorExpr:
+		v, ok := e.eval(x.X)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		v, ok = indirect(v)
+		if !ok || v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		field := v.FieldByName(x.Sel.Name)
+		return field, field.IsValid()
+
+	case *ast.IndexExpr:
+		v, ok := e.eval(x.X)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		index, ok := e.eval(x.Index)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		return evalIndex(v, index)
+
+	case *ast.CallExpr:
+		fun, ok := x.Fun.(*ast.Ident)
+		if !ok || (fun.Name != "len" && fun.Name != "cap") || len(x.Args) != 1 {
+			return reflect.Value{}, false
+		}
+		if _, ok := e.vars[fun.Name]; ok {
+			return reflect.Value{}, false
+		}
+		v, ok := e.eval(x.Args[0])
+		if !ok {
+			return reflect.Value{}, false
+		}
+		return evalLenCap(fun.Name, v)
+	}
+	return reflect.Value{}, false
+}
+
+func evalLiteral(lit *ast.BasicLit) (reflect.Value, bool) {
+	switch lit.Kind {
+	case token.INT:
+		i, err := strconv.ParseInt(lit.Value, 0, 64)
+		return reflect.ValueOf(int(i)), err == nil
+	case token.STRING:
+		s, err := strconv.Unquote(lit.Value)
+		return reflect.ValueOf(s), err == nil
+	}
+	return reflect.Value{}, false
+}
+
+// indirect follows pointers and interfaces, it fails on nil.
+func indirect(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+func evalIndex(v, index reflect.Value) (reflect.Value, bool) {
+	v, ok := indirect(v)
+	if !ok {
+		return reflect.Value{}, false
+	}
+
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice, reflect.String:
+		var i int64
+		switch {
+		case index.CanInt():
+			i = index.Int()
+		case index.CanUint() && index.Uint() <= uint64(v.Len()):
+			i = int64(index.Uint())
+		default:
+			return reflect.Value{}, false
+		}
+		if i < 0 || i >= int64(v.Len()) {
+			return reflect.Value{}, false
+		}
+		return v.Index(int(i)), true
+
+	case reflect.Map:
+		key := v.Type().Key()
+		if !index.Type().AssignableTo(key) {
+			// Integers are converted to integer keys and strings to string
+			// keys, but not integers to strings.
+			if !(index.CanInt() && isInt(key.Kind())) && !(index.Kind() == reflect.String && key.Kind() == reflect.String) {
+				return reflect.Value{}, false
+			}
+			index = index.Convert(key)
+		}
+		elem := v.MapIndex(index)
+		return elem, elem.IsValid()
+	}
+	return reflect.Value{}, false
+}
+
+func evalLenCap(fun string, v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Array {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Slice:
+		if fun == "cap" {
+			return reflect.ValueOf(v.Cap()), true
+		}
+		return reflect.ValueOf(v.Len()), true
+	case reflect.Map, reflect.String:
+		if fun == "len" {
+			return reflect.ValueOf(v.Len()), true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const conditionSrc = `package a_test
+
+import (
+	"testing"
+
+	"github.com/go-repo/assert"
+)
+
+func TestA(t *testing.T) {
+	assert.True(t, len(users) > 3 && users[0].Active, assert.Vars("users", users))
+	assert.New(t).False(ids["b"] == n || f(x), assert.Vars("ids", ids, "n", n))
+	assert.Condition(t, func() bool { return (*p).Name != "" }, assert.Vars("p", p))
+	assert.Condition(t, check)
+}
+`
+
+type user struct {
+	Name   string
+	Active bool
+}
+
+func TestSprintCondition(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "a_test.go")
+	err := os.WriteFile(file, []byte(conditionSrc), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		line      int
+		assertion string
+		vars      map[string]interface{}
+		expected  string
+	}{
+		{
+			10, "assert.True",
+			map[string]interface{}{"users": []user{{Name: "a"}}},
+			`len(users) > 3 && users[0].Active
+  len(users) = 1
+  users = []internal.user{internal.user{Name:"a", Active:false}}
+  users[0].Active = false
+  users[0] = internal.user{Name:"a", Active:false}`,
+		},
+		{
+			11, "assert.(*Assertions).False",
+			map[string]interface{}{"ids": map[string]int64{"b": 2}, "n": 2},
+			`ids["b"] == n || f(x)
+  ids["b"] = 2
+  ids = map[string]int64{"b":2}
+  n = 2`,
+		},
+		{
+			12, "assert.Condition",
+			map[string]interface{}{"p": &user{Name: ""}},
+			`(*p).Name != ""
+  (*p).Name = ""
+  p = &internal.user{Name:"", Active:false}`,
+		},
+		{13, "assert.Condition", nil, `check`},
+	} {
+		s, call, err := assertionCall(file, c.line, c.assertion)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual := sprintCondition(s, conditionExpr(s.assertionArgs(call)[0]), c.vars)
+		if actual != c.expected {
+			t.Errorf("line %v: expected:\n%s\nbut got:\n%s", c.line, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Context of key/value pairs which are prefixed to the failure messages of
+// a test and its subtests.
+type Context struct {
+	test  string
+	pairs []interface{}
+}
+
+// contexts of tests by their names.
+var contexts struct {
+	sync.Mutex
+	tests map[string][]*Context
+}
+
+func With(t *testing.T, keysAndValues ...interface{}) *Context {
+	ctx := &Context{test: t.Name(), pairs: keysAndValues}
+
+	contexts.Lock()
+	if contexts.tests == nil {
+		contexts.tests = map[string][]*Context{}
+	}
+	contexts.tests[ctx.test] = append(contexts.tests[ctx.test], ctx)
+	contexts.Unlock()
+
+	t.Cleanup(ctx.Close)
+	return ctx
+}
+
+// Close removes the pairs of the context from the failure messages, it's
+// called when the test ends.
+func (ctx *Context) Close() {
+	contexts.Lock()
+	defer contexts.Unlock()
+
+	scopes := contexts.tests[ctx.test]
+	for i, scope := range scopes {
+		if scope == ctx {
+			scopes = append(scopes[:i:i], scopes[i+1:]...)
+			break
+		}
+	}
+
+	if len(scopes) == 0 {
+		delete(contexts.tests, ctx.test)
+	} else {
+		contexts.tests[ctx.test] = scopes
+	}
+}
+
+// contextPrefix returns the pairs of the contexts of a test and its parents
+// like "key1=value1, key2=value2", a later value of a key replaces the
+// former one.
+func contextPrefix(test string) string {
+	contexts.Lock()
+	defer contexts.Unlock()
+
+	var keys []string
+	values := map[string]string{}
+	add := func(key, value string) {
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = value
+	}
+
+	for i := 0; i <= len(test); i++ {
+		if i < len(test) && test[i] != '/' {
+			continue
+		}
+
+		for _, ctx := range contexts.tests[test[:i]] {
+			for j := 0; j < len(ctx.pairs); j += 2 {
+				if j+1 == len(ctx.pairs) {
+					add(fmt.Sprint(ctx.pairs[j]), "<missing>")
+					break
+				}
+				add(fmt.Sprint(ctx.pairs[j]), fmt.Sprint(ctx.pairs[j+1]))
+			}
+		}
+	}
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+values[key])
+	}
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-repo/assert/diff"
+)
+
+const (
+	// EventEnvKey is the environment variable which makes failed assertions
+	// log a Record in addition to the message.
+	EventEnvKey = "ASSERT_JSON_EVENTS"
+	// EventPrefix starts a logged Record, the rest of the line is the Record
+	// in JSON.
+	EventPrefix = "ASSERT_FAILURE_JSON: "
+)
+
+// Record of a failed assertion.
+type Record struct {
+	// Package is set by the decoder of a test2json stream.
+	Package   string `json:"package,omitempty"`
+	Test      string `json:"test"`
+	Assertion string `json:"assertion,omitempty"`
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Message   string `json:"message"`
+	// Changed values of the diff, empty if the assertion has no diff.
+	Diff []Change `json:"diff,omitempty"`
+}
+
+// Change of a value in a diff, Actual or Expected is nil if the value only
+// exists in the other.
+type Change struct {
+	// Path of the value, e.g. ".Items[2].Price".
+	Path     string `json:"path"`
+	Actual   *Value `json:"actual,omitempty"`
+	Expected *Value `json:"expected,omitempty"`
+	Note     string `json:"note,omitempty"`
+}
+
+type Value struct {
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value"`
+}
+
+func eventsEnabled() bool {
+	on, _ := strconv.ParseBool(os.Getenv(EventEnvKey))
+	return on
+}
+
+func changeValue(xy *diff.XY) *Value {
+	if xy == nil {
+		return nil
+	}
+	return &Value{Type: xy.Type, Value: xy.Val}
+}
+
+// changes returns the changed values of a diff tree in depth-first order.
+func changes(node *diff.Node, path string, kind reflect.Kind, result []Change) []Change {
+	for _, child := range node.Children {
+		if child.DiffXY == nil && child.DiffNum == 0 {
+			continue
+		}
+
+		childPath := path + pathSegment(kind, child.Key)
+		if child.DiffXY != nil {
+			result = append(result, Change{
+				Path:     childPath,
+				Actual:   changeValue(child.DiffXY.X),
+				Expected: changeValue(child.DiffXY.Y),
+				Note:     child.DiffXY.Note,
+			})
+			continue
+		}
+
+		result = changes(child, childPath, child.Kind, result)
+	}
+	return result
+}
+
+func newRecord(f Failure) Record {
+	r := Record{
+		Test:      f.Test,
+		Assertion: f.Assertion,
+		File:      f.File,
+		Line:      f.Line,
+		Message:   f.Message,
+	}
+	if f.Tree != nil {
+		r.Diff = changes(f.Tree, "", reflect.Invalid, nil)
+	}
+	return r
+}
+
+// reportEvent logs the Record of a failure in a single line, so it's kept
+// in one output event by test2json.
+func reportEvent(t *testing.T, f Failure) {
+	t.Helper()
+
+	if !eventsEnabled() {
+		return
+	}
+
+	data, err := json.Marshal(newRecord(f))
+	if err != nil {
+		t.Logf("Can't encode failure record: %v", err)
+		return
+	}
+	t.Log(EventPrefix + string(data))
+}
+
+// ParseEvent returns the Record logged in a line of test output.
+func ParseEvent(line string) (Record, bool) {
+	i := strings.Index(line, EventPrefix)
+	if i < 0 {
+		return Record{}, false
+	}
+
+	var r Record
+	err := json.Unmarshal([]byte(strings.TrimSpace(line[i+len(EventPrefix):])), &r)
+	return r, err == nil
+}
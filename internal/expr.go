@@ -0,0 +1,151 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+type source struct {
+	modTime time.Time
+	size    int64
+	src     []byte
+	fset    *token.FileSet
+	file    *ast.File
+	imports map[string]bool
+}
+
+// Parsed source files by path, a file is parsed again if it changed, e.g.
+// by a snapshot update.
+var sources struct {
+	sync.Mutex
+	files map[string]*source
+}
+
+func parseSource(path string) (*source, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sources.Lock()
+	defer sources.Unlock()
+
+	if s, ok := sources.files[path]; ok && s.modTime.Equal(info.ModTime()) && s.size == info.Size() {
+		return s, nil
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &source{modTime: info.ModTime(), size: info.Size(), src: src, fset: fset, file: f, imports: importNames(f)}
+	if sources.files == nil {
+		sources.files = map[string]*source{}
+	}
+	sources.files[path] = s
+	return s, nil
+}
+
+func (s *source) text(n ast.Node) string {
+	return string(s.src[s.fset.Position(n.Pos()).Offset:s.fset.Position(n.End()).Offset])
+}
+
+// findCall returns the innermost call of a function, or of a method if
+// method is true, with the name at the line, which is between the start of
+// the call and its parenthesis.
+func (s *source) findCall(name string, method bool, line int) *ast.CallExpr {
+	var call *ast.CallExpr
+	ast.Inspect(s.file, func(n ast.Node) bool {
+		c, ok := n.(*ast.CallExpr)
+		if ok && callName(c) == name && s.isMethodCall(c) == method &&
+			s.fset.Position(c.Pos()).Line <= line && line <= s.fset.Position(c.Lparen).Line {
+			call = c
+		}
+		return true
+	})
+	return call
+}
+
+func callName(call *ast.CallExpr) string {
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		return fun.Sel.Name
+	case *ast.Ident:
+		return fun.Name
+	case *ast.IndexExpr:
+		return callName(&ast.CallExpr{Fun: fun.X})
+	}
+	return ""
+}
+
+// isMethodCall returns whether a call isn't called on a package.
+func (s *source) isMethodCall(call *ast.CallExpr) bool {
+	fun := call.Fun
+	if index, ok := fun.(*ast.IndexExpr); ok {
+		fun = index.X
+	}
+	if sel, ok := fun.(*ast.SelectorExpr); ok {
+		x, ok := sel.X.(*ast.Ident)
+		return !ok || !s.imports[x.Name]
+	}
+	return false
+}
+
+// assertionArgs returns the arguments of an assertion call without the
+// test, which isn't passed to the methods of Assertions.
+func (s *source) assertionArgs(call *ast.CallExpr) []ast.Expr {
+	if s.isMethodCall(call) {
+		return call.Args
+	}
+	if len(call.Args) == 0 {
+		return nil
+	}
+	return call.Args[1:]
+}
+
+// assertionCall returns the source and the call of an assertion, e.g.
+// "assert.Equal", at the line of file.
+func assertionCall(file string, line int, assertion string) (*source, *ast.CallExpr, error) {
+	s, err := parseSource(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rewriteMu.Lock()
+	line = currentLine(file, line)
+	rewriteMu.Unlock()
+
+	name := assertion[strings.LastIndexByte(assertion, '.')+1:]
+	call := s.findCall(name, strings.Contains(assertion, "("), line)
+	if call == nil {
+		return nil, nil, fmt.Errorf("%s call not found at %s:%d", assertion, file, line)
+	}
+	return s, call, nil
+}
+
+// sprintExpressions returns the source text of the actual and expected
+// arguments of the assertion at the line of file.
+func sprintExpressions(file string, line int, assertion string) (string, error) {
+	s, call, err := assertionCall(file, line, assertion)
+	if err != nil {
+		return "", err
+	}
+
+	args := s.assertionArgs(call)
+	if len(args) < 2 {
+		return "", fmt.Errorf("actual and expected arguments not found at %s:%d", file, line)
+	}
+	return fmt.Sprintf("actual:   %s\nexpected: %s\n", s.text(args[0]), s.text(args[1])), nil
+}
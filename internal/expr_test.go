@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const exprSrc = `package a_test
+
+import (
+	"testing"
+
+	"github.com/go-repo/assert"
+	ea "github.com/go-repo/assert/errorassert"
+)
+
+func TestA(t *testing.T) {
+	assert.Equal(t, resp.Items[0].Price, want)
+	ea.JSONEq(t,
+		string(body), ` + "`{\"a\": 1}`" + `)
+	assert.New(t).Equal(f(g(x), 1), y, assert.Msg("m"))
+	assert.Equal(t, assert.New(t).Equal(a, b), c)
+}
+`
+
+func TestSprintExpressions(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "a_test.go")
+	err := os.WriteFile(file, []byte(exprSrc), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		line      int
+		assertion string
+		expected  string
+	}{
+		{11, "assert.Equal", "actual:   resp.Items[0].Price\nexpected: want\n"},
+		{12, "errorassert.JSONEq", "actual:   string(body)\nexpected: `{\"a\": 1}`\n"},
+		{14, "assert.(*Assertions).Equal", "actual:   f(g(x), 1)\nexpected: y\n"},
+		{15, "assert.(*Assertions).Equal", "actual:   a\nexpected: b\n"},
+		{15, "assert.Equal", "actual:   assert.New(t).Equal(a, b)\nexpected: c\n"},
+	} {
+		actual, err := sprintExpressions(file, c.line, c.assertion)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != c.expected {
+			t.Errorf("line %v: expected %q but got %q", c.line, c.expected, actual)
+		}
+	}
+
+	_, err = sprintExpressions(file, 10, "assert.Equal")
+	if err == nil {
+		t.Error("expected error for line without call")
+	}
+}
+
+func TestParseSource__Cache(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "a_test.go")
+	err := os.WriteFile(file, []byte(exprSrc), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s1, err := parseSource(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2, err := parseSource(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s1 != s2 {
+		t.Error("expected cached source")
+	}
+
+	err = os.WriteFile(file, []byte(exprSrc+"\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s3, err := parseSource(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s3 == s1 {
+		t.Error("expected source parsed again after a change")
+	}
+}
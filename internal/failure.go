@@ -0,0 +1,108 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-repo/assert/diff"
+)
+
+// Failure of an assertion which is passed to the reports.
+type Failure struct {
+	Test string
+	// Assertion function called by the test, e.g. "assert.Equal".
+	Assertion string
+	File      string
+	Line      int
+	Message   string
+	// Diff tree of actual and expected, nil if the assertion has no diff.
+	Tree *diff.Node
+}
+
+var failureHooks struct {
+	sync.Mutex
+	hooks []func(Failure)
+}
+
+// OnFailure calls hook with every failure of an assertion, it may be called
+// from several goroutines at once.
+func OnFailure(hook func(Failure)) {
+	failureHooks.Lock()
+	defer failureHooks.Unlock()
+
+	failureHooks.hooks = append(failureHooks.hooks, hook)
+}
+
+func callFailureHooks(f Failure) {
+	failureHooks.Lock()
+	hooks := failureHooks.hooks
+	failureHooks.Unlock()
+
+	for _, hook := range hooks {
+		hook(f)
+	}
+}
+
+// Fail logs the message of a failed assertion, prefixed by the context of
+// the test and the message of the options, or passes it to the collector of
+// the options, and adds it to the reports which are enabled.
+func Fail(t *testing.T, opts []Option, msg string, tree *diff.Node) {
+	t.Helper()
+
+	f := Failure{Test: t.Name(), Tree: tree}
+	if frame, fn, ok := caller(); ok {
+		f.Assertion = funcName(fn)
+		f.File = frame.File
+		f.Line = frame.Line
+	}
+
+	c := NewConfig(opts)
+	if c.Expressions && tree != nil && f.File != "" {
+		exprs, err := sprintExpressions(f.File, f.Line, f.Assertion)
+		if err != nil {
+			t.Logf("Can't print expressions: %v", err)
+		}
+		msg = exprs + msg
+	}
+	if c.Message != "" {
+		msg = c.Message + ": " + msg
+	}
+	if prefix := contextPrefix(t.Name()); prefix != "" {
+		msg = prefix + ": " + msg
+	}
+	f.Message = strings.TrimRight(msg, "\n")
+
+	if c.Collect != nil {
+		c.Collect(f)
+	} else {
+		t.Log(msg)
+	}
+
+	reportEvent(t, f)
+	callFailureHooks(f)
+
+	err := reportHTML(f)
+	if err != nil {
+		t.Logf("Can't write HTML report: %v", err)
+	}
+
+	err = reportAnnotation(f)
+	if err != nil {
+		t.Logf("Can't write annotation: %v", err)
+	}
+}
+
+// pathSegment returns the segment of a path to a child of a level in a
+// diff tree, e.g. ".Field" or "[2]", empty if the child has the path of its
+// parent.
+func pathSegment(parentKind reflect.Kind, key string) string {
+	switch parentKind {
+	case reflect.Struct:
+		return "." + key
+	case reflect.Array, reflect.Map, reflect.Slice:
+		return "[" + key + "]"
+	}
+	return ""
+}
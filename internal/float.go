@@ -0,0 +1,60 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/go-repo/assert/diff"
+)
+
+func within(
+	t *testing.T,
+	actual, expected interface{},
+	name string, tolerance interface{}, opt diff.Option,
+	opts []Option,
+) bool {
+	t.Helper()
+
+	fx, okX := ToFloat(actual)
+	fy, okY := ToFloat(expected)
+	if !okX || !okY {
+		Fail(t, opts, fmt.Sprintf("Actual and expected must be numbers: %#v, %#v", actual, expected), nil)
+		return false
+	}
+
+	// Keep floats of the same type so ulps are counted in their precision.
+	var x, y interface{} = fx, fy
+	if reflect.TypeOf(actual) == reflect.TypeOf(expected) &&
+		isFloat(reflect.TypeOf(actual).Kind()) {
+		x, y = actual, expected
+	}
+
+	diffOpts := append(NewConfig(opts).DiffOptions, opt)
+	tree, ok := equal(x, y, diffOpts)
+	if ok {
+		return true
+	}
+
+	Fail(t, opts, fmt.Sprintf("Actual (-) and expected (+) are not within %s %v:\n", name, tolerance)+
+		diff.DiffTree(tree, diffOpts...), tree)
+	return false
+}
+
+func InDelta(t *testing.T, actual, expected interface{}, delta float64, opts ...Option) bool {
+	t.Helper()
+
+	return within(t, actual, expected, "delta", delta, diff.FloatDelta(delta), opts)
+}
+
+func InEpsilon(t *testing.T, actual, expected interface{}, epsilon float64, opts ...Option) bool {
+	t.Helper()
+
+	return within(t, actual, expected, "epsilon", epsilon, diff.FloatEpsilon(epsilon), opts)
+}
+
+func WithinULP(t *testing.T, actual, expected interface{}, ulps uint64, opts ...Option) bool {
+	t.Helper()
+
+	return within(t, actual, expected, "ulps", ulps, diff.FloatULP(ulps), opts)
+}
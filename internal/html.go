@@ -0,0 +1,205 @@
+package internal
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/go-repo/assert/diff"
+)
+
+// HTMLReportEnvKey is the environment variable with the path of an HTML
+// report of all assertion failures of the test binary, the report is
+// rewritten after each failure. A relative path is relative to the package
+// directory of the tests. The package path is added to the file name, see
+// PackageFile.
+const HTMLReportEnvKey = "ASSERT_HTML_REPORT"
+
+var htmlReport struct {
+	sync.Mutex
+	failures []Failure
+}
+
+func reportHTML(f Failure) error {
+	path := os.Getenv(HTMLReportEnvKey)
+	if path == "" {
+		return nil
+	}
+	path = PackageFile(path)
+
+	htmlReport.Lock()
+	defer htmlReport.Unlock()
+
+	htmlReport.failures = append(htmlReport.failures, f)
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(sprintHTMLReport(htmlReport.failures)), 0644)
+}
+
+const htmlStyle = `
+body { font-family: sans-serif; margin: 2em; color: #24292f; }
+section { border: 1px solid #d0d7de; border-radius: 6px; margin: 1em 0; padding: 0 1em 1em; }
+h2 { font-size: 1.1em; }
+.assertion, .location { color: #57606a; font-weight: normal; margin-left: 1em; }
+code, pre { font-family: monospace; }
+details details, .leaf { margin-left: 1.5em; }
+summary { cursor: pointer; }
+.count { color: #57606a; margin-left: 1em; }
+.leaf { margin-top: .3em; margin-bottom: .3em; }
+.path { color: #57606a; font-size: .9em; }
+.path span + span::before { content: " \203A  "; }
+.x { background: #ffebe9; }
+.y { background: #e6ffec; }
+.x mark { background: #ff8182; }
+.y mark { background: #abf2bc; }
+.note { color: #57606a; }
+`
+
+// sprintHTMLReport renders failures as a self-contained HTML page, diff
+// trees are rendered as collapsible levels with the path of each changed
+// value.
+func sprintHTMLReport(failures []Failure) string {
+	b := &strings.Builder{}
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	b.WriteString("<title>Assertion failures</title>\n<style>" + htmlStyle + "</style>\n</head>\n<body>\n")
+	fmt.Fprintf(b, "<h1>%d assertion failures</h1>\n", len(failures))
+
+	for _, f := range failures {
+		b.WriteString("<section>\n<h2>")
+		b.WriteString(html.EscapeString(f.Test))
+		if f.Assertion != "" {
+			fmt.Fprintf(b, "<span class=\"assertion\">%s</span>", html.EscapeString(f.Assertion))
+		}
+		if f.File != "" {
+			fmt.Fprintf(b, "<span class=\"location\">%s:%d</span>", html.EscapeString(f.File), f.Line)
+		}
+		b.WriteString("</h2>\n")
+
+		if f.Tree == nil {
+			fmt.Fprintf(b, "<pre>%s</pre>\n", html.EscapeString(f.Message))
+		} else {
+			title, _, _ := strings.Cut(f.Message, "\n")
+			fmt.Fprintf(b, "<p>%s</p>\n", html.EscapeString(title))
+			sprintHTMLTree(b, f.Tree, nil, reflect.Invalid)
+			fmt.Fprintf(b, "<details>\n<summary>Text</summary>\n<pre>%s</pre>\n</details>\n",
+				html.EscapeString(f.Message))
+		}
+		b.WriteString("</section>\n")
+	}
+
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+func sprintHTMLTree(b *strings.Builder, node *diff.Node, path []string, kind reflect.Kind) {
+	for _, child := range node.Children {
+		if child.DiffXY == nil && child.DiffNum == 0 {
+			continue
+		}
+
+		childPath := path
+		if segment := pathSegment(kind, child.Key); segment != "" {
+			childPath = append(path[:len(path):len(path)], segment)
+		}
+
+		if child.DiffXY != nil {
+			sprintHTMLLeaf(b, child.DiffXY, childPath)
+			continue
+		}
+
+		switch child.Kind {
+		case reflect.Array, reflect.Map, reflect.Slice, reflect.Struct:
+			b.WriteString("<details open>\n<summary><code>")
+			if len(childPath) > 0 {
+				b.WriteString(html.EscapeString(childPath[len(childPath)-1]) + " ")
+			}
+			fmt.Fprintf(b, "%s</code><span class=\"count\">%d changed</span></summary>\n",
+				html.EscapeString(child.Type), child.DiffNum)
+			sprintHTMLTree(b, child, childPath, child.Kind)
+			b.WriteString("</details>\n")
+		default:
+			sprintHTMLTree(b, child, childPath, child.Kind)
+		}
+	}
+}
+
+func sprintHTMLLeaf(b *strings.Builder, xy *diff.DiffXY, path []string) {
+	b.WriteString("<div class=\"leaf\">\n")
+	if len(path) > 0 {
+		b.WriteString("<div class=\"path\">")
+		for _, segment := range path {
+			fmt.Fprintf(b, "<span>%s</span>", html.EscapeString(segment))
+		}
+		b.WriteString("</div>\n")
+	}
+
+	var xVal, yVal string
+	if xy.X != nil && xy.Y != nil && xy.X.Kind == reflect.String && xy.Y.Kind == reflect.String {
+		xVal, yVal = highlightChange(xy.X.Val, xy.Y.Val)
+	} else {
+		if xy.X != nil {
+			xVal = html.EscapeString(xy.X.Val)
+		}
+		if xy.Y != nil {
+			yVal = html.EscapeString(xy.Y.Val)
+		}
+	}
+
+	if xy.X != nil {
+		fmt.Fprintf(b, "<div class=\"x\"><code>- %s</code></div>\n", htmlXY(xy.X, xVal))
+	}
+	if xy.Y != nil {
+		fmt.Fprintf(b, "<div class=\"y\"><code>+ %s</code></div>\n", htmlXY(xy.Y, yVal))
+	}
+	if xy.Note != "" {
+		fmt.Fprintf(b, "<div class=\"note\"><code>// %s</code></div>\n", html.EscapeString(xy.Note))
+	}
+	b.WriteString("</div>\n")
+}
+
+func htmlXY(xy *diff.XY, val string) string {
+	if xy.Type == "" {
+		return val
+	}
+	return html.EscapeString(xy.Type) + "(" + val + ")"
+}
+
+// highlightChange escapes x and y and marks the part between their common
+// prefix and suffix.
+func highlightChange(x, y string) (string, string) {
+	prefix := 0
+	for prefix < len(x) && prefix < len(y) && x[prefix] == y[prefix] {
+		prefix++
+	}
+	for prefix > 0 && prefix < len(x) && prefix < len(y) &&
+		(!utf8.RuneStart(x[prefix]) || !utf8.RuneStart(y[prefix])) {
+		prefix--
+	}
+
+	// The suffix doesn't overlap the prefix, e.g. of "aa" and "aaa".
+	suffix := 0
+	for suffix < len(x)-prefix && suffix < len(y)-prefix &&
+		x[len(x)-1-suffix] == y[len(y)-1-suffix] {
+		suffix++
+	}
+	for suffix > 0 && (!utf8.RuneStart(x[len(x)-suffix]) || !utf8.RuneStart(y[len(y)-suffix])) {
+		suffix--
+	}
+
+	mark := func(s string) string {
+		changed := s[prefix : len(s)-suffix]
+		if changed != "" {
+			changed = "<mark>" + html.EscapeString(changed) + "</mark>"
+		}
+		return html.EscapeString(s[:prefix]) + changed + html.EscapeString(s[len(s)-suffix:])
+	}
+	return mark(x), mark(y)
+}
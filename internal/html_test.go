@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-repo/assert/diff"
+)
+
+type htmlItem struct {
+	Name  string
+	Price float64
+}
+
+func TestHighlightChange(t *testing.T) {
+	x, y := highlightChange(`"a<b>c"`, `"a<x>c"`)
+	if x != `&#34;a&lt;<mark>b</mark>&gt;c&#34;` || y != `&#34;a&lt;<mark>x</mark>&gt;c&#34;` {
+		t.Fatalf("unexpected highlight: %s, %s", x, y)
+	}
+
+	x, y = highlightChange(`"ab"`, `"abc"`)
+	if x != `&#34;ab&#34;` || y != `&#34;ab<mark>c</mark>&#34;` {
+		t.Fatalf("unexpected highlight: %s, %s", x, y)
+	}
+
+	x, y = highlightChange(`"é"`, `"è"`)
+	if x != `&#34;<mark>é</mark>&#34;` || y != `&#34;<mark>è</mark>&#34;` {
+		t.Fatalf("unexpected highlight: %s, %s", x, y)
+	}
+
+	x, y = highlightChange(`a`, `a`)
+	if x != `a` || y != `a` {
+		t.Fatalf("unexpected highlight: %s, %s", x, y)
+	}
+
+	x, y = highlightChange(`ab`, `abc`)
+	if x != `ab` || y != `ab<mark>c</mark>` {
+		t.Fatalf("unexpected highlight: %s, %s", x, y)
+	}
+
+	x, y = highlightChange(`aé`, `a`)
+	if x != `a<mark>é</mark>` || y != `a` {
+		t.Fatalf("unexpected highlight: %s, %s", x, y)
+	}
+}
+
+func TestReportHTML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report", "index.html")
+	t.Setenv(HTMLReportEnvKey, path)
+	defer func() {
+		htmlReport.failures = nil
+	}()
+
+	for _, f := range []Failure{
+		{
+			Test:      "TestA",
+			Assertion: "assert.Equal",
+			File:      "/src/a_test.go",
+			Line:      10,
+			Message:   "Actual (-) and expected (+) are not equal:",
+			Tree: diff.Tree(
+				map[string][]htmlItem{"items": {{Name: "apple", Price: 1}}},
+				map[string][]htmlItem{"items": {{Name: "apricot", Price: 2}}},
+			),
+		},
+		{
+			Test:      "TestB/sub",
+			Assertion: "errorassert.NoError",
+			File:      "/src/a_test.go",
+			Line:      20,
+			Message:   "Got unexpected error: <err>",
+		},
+	} {
+		err := reportHTML(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(path), "index.github.com_go-repo_assert_internal.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := string(data)
+
+	for _, expected := range []string{
+		"<h1>2 assertion failures</h1>",
+		`<h2>TestA<span class="assertion">assert.Equal</span><span class="location">/src/a_test.go:10</span></h2>`,
+		`<summary><code>[&#34;items&#34;] []internal.htmlItem</code><span class="count">2 changed</span></summary>`,
+		`<div class="path"><span>[&#34;items&#34;]</span><span>[0]</span><span>.Name</span></div>`,
+		`<div class="x"><code>- string(&#34;ap<mark>ple</mark>&#34;)</code></div>`,
+		`<div class="y"><code>+ string(&#34;ap<mark>ricot</mark>&#34;)</code></div>`,
+		`<div class="x"><code>- float64(1)</code></div>`,
+		"<pre>Got unexpected error: &lt;err&gt;</pre>",
+	} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("report doesn't contain %s:\n%s", expected, report)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/go-repo/assert/diff"
+)
+
+func readJSON(i interface{}) ([]byte, error) {
+	switch v := i.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case json.RawMessage:
+		return v, nil
+	case io.Reader:
+		return io.ReadAll(v)
+	}
+	return nil, fmt.Errorf("unsupported type %T, expected string, []byte, json.RawMessage or io.Reader", i)
+}
+
+// jsonNumber is a JSON number which a float64 can't hold exactly, in its
+// shortest decimal form, so it's compared exactly.
+type jsonNumber string
+
+// exactNumber returns a JSON number as a float64 if it holds the number
+// exactly, e.g. 0.1 or 1e3, otherwise as a jsonNumber.
+func exactNumber(n json.Number) (interface{}, error) {
+	r, ok := new(big.Rat).SetString(string(n))
+	if !ok {
+		return nil, fmt.Errorf("invalid number %s", n)
+	}
+
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err == nil {
+		if fr, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64)); ok && fr.Cmp(r) == 0 {
+			return f, nil
+		}
+	}
+
+	if r.IsInt() {
+		return jsonNumber(r.Num().String()), nil
+	}
+	for prec := 1; ; prec++ {
+		s := r.FloatString(prec)
+		if sr, _ := new(big.Rat).SetString(s); sr.Cmp(r) == 0 {
+			return jsonNumber(s), nil
+		}
+	}
+}
+
+// exactNumbers replaces the json.Numbers of a decoded JSON value.
+func exactNumbers(v interface{}) (interface{}, error) {
+	var err error
+	switch v := v.(type) {
+	case json.Number:
+		return exactNumber(v)
+	case map[string]interface{}:
+		for key, elem := range v {
+			if v[key], err = exactNumbers(elem); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, elem := range v {
+			if v[i], err = exactNumbers(elem); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return v, nil
+}
+
+// decodeJSON decodes numbers as float64 if it holds them exactly, large
+// integers and numbers with many digits as jsonNumber.
+func decodeJSON(i interface{}) (interface{}, error) {
+	data, err := readJSON(i)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the data first for the errors of json.Unmarshal, a decoder
+	// doesn't fail on trailing data.
+	err = json.Unmarshal(data, new(json.RawMessage))
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err = decoder.Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+	return exactNumbers(v)
+}
+
+var jsonIdentRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+func jsonPathKey(path, key string) string {
+	if jsonIdentRegexp.MatchString(key) {
+		return path + "." + key
+	}
+
+	b, _ := json.Marshal(key)
+	return path + "[" + string(b) + "]"
+}
+
+// sprintJSONVal formats a decoded JSON value with its JSON type.
+func sprintJSONVal(v interface{}) string {
+	if v == nil {
+		return "null"
+	}
+
+	if n, ok := v.(jsonNumber); ok {
+		return "number(" + string(n) + ")"
+	}
+
+	b, _ := json.Marshal(v)
+	switch v.(type) {
+	case map[string]interface{}:
+		return "object(" + string(b) + ")"
+	case []interface{}:
+		return "array(" + string(b) + ")"
+	case string:
+		return "string(" + string(b) + ")"
+	case float64:
+		return "number(" + string(b) + ")"
+	case bool:
+		return "boolean(" + string(b) + ")"
+	}
+	return string(b)
+}
+
+// child returns the element of a decoded JSON value by a key of the diff
+// tree.
+func jsonChild(v interface{}, parentKind reflect.Kind, key string) (interface{}, bool) {
+	switch parentKind {
+	case reflect.Map:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		c, ok := m[key]
+		return c, ok
+	case reflect.Slice:
+		s, ok := v.([]interface{})
+		i, err := strconv.Atoi(key)
+		if !ok || err != nil || i >= len(s) {
+			return nil, false
+		}
+		return s[i], true
+	}
+	return v, true
+}
+
+// sprintJSONTree renders differences of a diff tree of two decoded JSON
+// values as JSON paths, values are looked up in x and y by the path.
+func sprintJSONTree(node *diff.Node, path string, x, y interface{}, buffer *bytes.Buffer) {
+	for _, child := range node.Children {
+		if child.DiffXY == nil && child.DiffNum == 0 {
+			continue
+		}
+
+		key := child.Key
+		childPath := path
+		switch node.Kind {
+		case reflect.Map:
+			key, _ = strconv.Unquote(child.Key)
+			childPath = jsonPathKey(path, key)
+		case reflect.Slice:
+			childPath = path + "[" + key + "]"
+		}
+
+		cx, okX := jsonChild(x, node.Kind, key)
+		cy, okY := jsonChild(y, node.Kind, key)
+
+		if child.DiffXY == nil {
+			sprintJSONTree(child, childPath, cx, cy, buffer)
+			continue
+		}
+
+		if child.DiffXY.X != nil && okX {
+			buffer.WriteString(fmt.Sprintf("- %s: %s\n", childPath, sprintJSONVal(cx)))
+		}
+		if child.DiffXY.Y != nil && okY {
+			buffer.WriteString(fmt.Sprintf("+ %s: %s\n", childPath, sprintJSONVal(cy)))
+		}
+	}
+}
+
+func JSONEq(t *testing.T, actual, expected interface{}, opts ...Option) bool {
+	t.Helper()
+
+	x, err := decodeJSON(actual)
+	if err != nil {
+		Fail(t, opts, fmt.Sprintf("Can't decode actual JSON: %v", err), nil)
+		return false
+	}
+
+	y, err := decodeJSON(expected)
+	if err != nil {
+		Fail(t, opts, fmt.Sprintf("Can't decode expected JSON: %v", err), nil)
+		return false
+	}
+
+	if reflect.DeepEqual(x, y) {
+		return true
+	}
+
+	tree := diff.Tree(x, y, NewConfig(opts).DiffOptions...)
+	if tree.DiffNum == 0 {
+		return true
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	sprintJSONTree(tree, "$", x, y, buffer)
+	Fail(t, opts, "Actual (-) and expected (+) JSON are not equal:\n"+buffer.String(), tree)
+	return false
+}
@@ -0,0 +1,261 @@
+package internal
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Functions of goroutines which are never leaks: goroutines running tests
+// and of signal handling, which runs until the program exits.
+var defaultIgnoredGoroutines = []string{
+	"testing.tRunner",
+	"testing.(*M).",
+	"testing.runFuzzing",
+	"os/signal.signal_recv",
+	"os/signal.loop",
+	"runtime.ensureSigM",
+}
+
+// Waiting times for goroutines to exit before they are leaks.
+const (
+	leakMinBackoff = time.Millisecond
+	leakMaxBackoff = 100 * time.Millisecond
+	leakTimeout    = time.Second
+)
+
+type goroutine struct {
+	id    int
+	state string
+	// Functions of the stack, innermost first.
+	funcs []string
+	// Stack with a function and its location per frame, without arguments
+	// and program counter offsets.
+	stack string
+	// "created by" function and location.
+	createdBy string
+	// ID of the goroutine which started it, 0 if unknown.
+	parent int
+}
+
+func (g goroutine) ignored(ignores []string) bool {
+	for _, fn := range g.funcs {
+		for _, ignore := range ignores {
+			if strings.HasPrefix(fn, ignore) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stackTrace returns the stack traces of the current goroutine or of all.
+func stackTrace(all bool) string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, all)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// parseGoroutines parses the output of runtime.Stack.
+func parseGoroutines(stacks string) []goroutine {
+	var goroutines []goroutine
+	for _, block := range strings.Split(strings.TrimSpace(stacks), "\n\n") {
+		lines := strings.Split(block, "\n")
+		g, ok := parseGoroutineHeader(lines[0])
+		if !ok {
+			continue
+		}
+
+		var stack strings.Builder
+		for i := 1; i+1 < len(lines); i += 2 {
+			fn := lines[i]
+			location := strings.TrimSpace(lines[i+1])
+			if j := strings.Index(location, " +0x"); j >= 0 {
+				location = location[:j]
+			}
+
+			if strings.HasPrefix(fn, "created by ") {
+				fn = strings.TrimPrefix(fn, "created by ")
+				if j := strings.Index(fn, " in goroutine "); j >= 0 {
+					g.parent, _ = strconv.Atoi(fn[j+len(" in goroutine "):])
+					fn = fn[:j]
+				}
+				g.createdBy = fn + " at " + location
+				continue
+			}
+
+			if j := strings.LastIndexByte(fn, '('); j > 0 && strings.HasSuffix(fn, ")") {
+				fn = fn[:j]
+			}
+			g.funcs = append(g.funcs, fn)
+			fmt.Fprintf(&stack, "%s()\n\t%s\n", fn, location)
+		}
+		g.stack = stack.String()
+		goroutines = append(goroutines, g)
+	}
+	return goroutines
+}
+
+// parseGoroutineHeader parses e.g. "goroutine 7 [chan receive]:".
+func parseGoroutineHeader(line string) (goroutine, bool) {
+	fields := strings.SplitN(strings.TrimSuffix(line, ":"), " ", 3)
+	if len(fields) < 3 || fields[0] != "goroutine" {
+		return goroutine{}, false
+	}
+	id, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return goroutine{}, false
+	}
+	// The state can have a waiting time, e.g. "[chan receive, 2 minutes]".
+	state := strings.Trim(fields[2], "[]")
+	if i := strings.IndexByte(state, ','); i >= 0 {
+		state = state[:i]
+	}
+	return goroutine{id: id, state: state}, true
+}
+
+// Goroutines returns the IDs of the current goroutines.
+func Goroutines() map[int]bool {
+	ids := map[int]bool{}
+	for _, g := range parseGoroutines(stackTrace(true)) {
+		ids[g.id] = true
+	}
+	return ids
+}
+
+// CurrentGoroutine returns the ID of the calling goroutine.
+func CurrentGoroutine() int {
+	if gs := parseGoroutines(stackTrace(false)); len(gs) == 1 {
+		return gs[0].id
+	}
+	return 0
+}
+
+// starter returns the test goroutine if it started g via goroutines which
+// are still running, otherwise the innermost running goroutine of another
+// test via which g was started, 0 if there is none.
+func starter(g goroutine, byID map[int]goroutine, test int) int {
+	other := 0
+	for id := g.parent; id != 0; {
+		if id == test {
+			return id
+		}
+		p, ok := byID[id]
+		if !ok {
+			break
+		}
+		if other == 0 && p.ignored([]string{"testing.tRunner"}) {
+			other = id
+		}
+		id = p.parent
+	}
+	return other
+}
+
+// leakedGoroutines returns the goroutines which were started by the test
+// goroutine or aren't in the snapshot and weren't started by another test,
+// after they had time to exit. Ignored goroutines aren't leaks.
+func leakedGoroutines(snapshot map[int]bool, test int, ignores []string) []goroutine {
+	current := CurrentGoroutine()
+	ignores = append(ignores[:len(ignores):len(ignores)], defaultIgnoredGoroutines...)
+
+	deadline := time.Now().Add(leakTimeout)
+	backoff := leakMinBackoff
+	for {
+		goroutines := parseGoroutines(stackTrace(true))
+		byID := map[int]goroutine{}
+		for _, g := range goroutines {
+			byID[g.id] = g
+		}
+
+		var leaks []goroutine
+		for _, g := range goroutines {
+			if g.id == current || g.ignored(ignores) {
+				continue
+			}
+			started := starter(g, byID, test)
+			if (test != 0 && started == test) || (started == 0 && !snapshot[g.id]) {
+				leaks = append(leaks, g)
+			}
+		}
+		if len(leaks) == 0 || time.Now().After(deadline) {
+			return leaks
+		}
+
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > leakMaxBackoff {
+			backoff = leakMaxBackoff
+		}
+	}
+}
+
+// sprintLeaks groups leaked goroutines by their creation site and stack.
+func sprintLeaks(leaks []goroutine) string {
+	type group struct {
+		goroutine
+		ids []int
+	}
+	groups := map[string]*group{}
+	var keys []string
+	for _, g := range leaks {
+		key := g.createdBy + "\n" + g.state + "\n" + g.stack
+		if groups[key] == nil {
+			groups[key] = &group{goroutine: g}
+			keys = append(keys, key)
+		}
+		groups[key].ids = append(groups[key].ids, g.id)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return groups[keys[i]].ids[0] < groups[keys[j]].ids[0]
+	})
+
+	var b strings.Builder
+	if len(leaks) == 1 {
+		b.WriteString("Found 1 leaked goroutine:")
+	} else {
+		fmt.Fprintf(&b, "Found %v leaked goroutines:", len(leaks))
+	}
+	for _, key := range keys {
+		g := groups[key]
+		ids := make([]string, len(g.ids))
+		for i, id := range g.ids {
+			ids[i] = strconv.Itoa(id)
+		}
+
+		fmt.Fprintf(&b, "\ngoroutine %s [%s] created by %s:\n", strings.Join(ids, ", "), g.state, g.createdBy)
+		b.WriteString("  " + strings.ReplaceAll(strings.TrimSuffix(g.stack, "\n"), "\n", "\n  "))
+	}
+	return b.String()
+}
+
+// GoroutineLeaks returns the failure message of the goroutines which were
+// started by the test goroutine or since the snapshot and didn't exit, empty
+// if there are none. test is 0 for no test goroutine.
+func GoroutineLeaks(snapshot map[int]bool, test int, opts ...Option) string {
+	leaks := leakedGoroutines(snapshot, test, NewConfig(opts).IgnoredGoroutines)
+	if len(leaks) == 0 {
+		return ""
+	}
+	return sprintLeaks(leaks)
+}
+
+func NoGoroutineLeaks(t *testing.T, snapshot map[int]bool, test int, opts ...Option) bool {
+	t.Helper()
+
+	msg := GoroutineLeaks(snapshot, test, opts...)
+	if msg == "" {
+		return true
+	}
+
+	Fail(t, opts, msg, nil)
+	return false
+}
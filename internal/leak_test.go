@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const goroutineStacks = `goroutine 7 [running]:
+x.TestX(0x3a0863aa2248?)
+	/tmp/x/x_test.go:3 +0x9f
+testing.tRunner(0x3a0863aa2248, 0x6d4468)
+	/usr/local/go/src/testing/testing.go:2193 +0xea
+created by testing.(*T).Run in goroutine 1
+	/usr/local/go/src/testing/testing.go:2258 +0x4d4
+
+goroutine 8 [chan receive, 2 minutes]:
+x.worker(...)
+	/tmp/x/x.go:10
+x.Start.func1()
+	/tmp/x/x.go:5 +0x1c
+created by x.Start in goroutine 7
+	/tmp/x/x.go:4 +0x76
+
+goroutine 9 [chan receive]:
+x.worker(...)
+	/tmp/x/x.go:10
+x.Start.func1()
+	/tmp/x/x.go:5 +0x1c
+created by x.Start in goroutine 7
+	/tmp/x/x.go:4 +0x76
+
+goroutine 10 [select]:
+net/http.(*persistConn).readLoop(0xc000)
+	/usr/local/go/src/net/http/transport.go:2200 +0x1c
+created by net/http.(*Transport).dialConn in goroutine 7
+	/usr/local/go/src/net/http/transport.go:1800 +0x76
+`
+
+func TestParseGoroutines(t *testing.T) {
+	goroutines := parseGoroutines(goroutineStacks)
+	if len(goroutines) != 4 {
+		t.Fatalf("expected 4 goroutines but got %v", len(goroutines))
+	}
+
+	g := goroutines[1]
+	if g.id != 8 || g.state != "chan receive" || g.createdBy != "x.Start at /tmp/x/x.go:4" || g.parent != 7 ||
+		strings.Join(g.funcs, " ") != "x.worker x.Start.func1" {
+		t.Errorf("unexpected goroutine: %+v", g)
+	}
+	if !goroutines[0].ignored(defaultIgnoredGoroutines) || goroutines[1].ignored(defaultIgnoredGoroutines) {
+		t.Error("expected only the test goroutine to be ignored by default")
+	}
+	if !goroutines[3].ignored([]string{"net/http.(*persistConn)"}) {
+		t.Error("expected ignored goroutine")
+	}
+
+	expected := `Found 3 leaked goroutines:
+goroutine 8, 9 [chan receive] created by x.Start at /tmp/x/x.go:4:
+  x.worker()
+  	/tmp/x/x.go:10
+  x.Start.func1()
+  	/tmp/x/x.go:5
+goroutine 10 [select] created by net/http.(*Transport).dialConn at /usr/local/go/src/net/http/transport.go:1800:
+  net/http.(*persistConn).readLoop()
+  	/usr/local/go/src/net/http/transport.go:2200`
+	if actual := sprintLeaks(goroutines[1:]); actual != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, actual)
+	}
+}
+
+func TestStarter(t *testing.T) {
+	byID := map[int]goroutine{}
+	for _, g := range parseGoroutines(goroutineStacks) {
+		byID[g.id] = g
+	}
+
+	if actual := starter(byID[8], byID, 7); actual != 7 {
+		t.Errorf("expected goroutine started by the test but got %v", actual)
+	}
+	if actual := starter(byID[8], byID, 3); actual != 7 {
+		t.Errorf("expected goroutine started by another test but got %v", actual)
+	}
+	if actual := starter(byID[7], byID, 3); actual != 0 {
+		t.Errorf("expected goroutine started by an exited goroutine but got %v", actual)
+	}
+}
+
+func TestGoroutineLeaks(t *testing.T) {
+	snapshot := Goroutines()
+	test := CurrentGoroutine()
+	done := make(chan struct{})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(done)
+	}()
+	if msg := GoroutineLeaks(snapshot, test); msg != "" {
+		t.Errorf("expected no leaks but got:\n%s", msg)
+	}
+
+	block := make(chan struct{})
+	defer close(block)
+	go func() {
+		<-block
+	}()
+	msg := GoroutineLeaks(snapshot, test)
+	if !strings.HasPrefix(msg, "Found 1 leaked goroutine:\n") || !strings.Contains(msg, "internal.TestGoroutineLeaks.func2()") {
+		t.Errorf("unexpected leaks:\n%s", msg)
+	}
+
+	// The goroutine is in a snapshot after it started, but it was started by
+	// the test goroutine.
+	if msg := GoroutineLeaks(Goroutines(), test); !strings.Contains(msg, "internal.TestGoroutineLeaks.func2()") {
+		t.Errorf("unexpected leaks:\n%s", msg)
+	}
+
+	ignore := func(c *Config) {
+		c.IgnoredGoroutines = []string{"github.com/go-repo/assert/internal.TestGoroutineLeaks"}
+	}
+	if msg := GoroutineLeaks(snapshot, test, ignore); msg != "" {
+		t.Errorf("expected ignored leaks but got:\n%s", msg)
+	}
+}
@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/go-repo/assert/diff"
+)
+
+// SideBySideEnvKey is the environment variable which makes assertions print
+// diffs side by side, its value is the width or true for diff.DefaultWidth.
+const SideBySideEnvKey = "ASSERT_SIDE_BY_SIDE"
+
+type Config struct {
+	DiffOptions []diff.Option
+	// Append the actual value as Go literal to a failure of Equal.
+	GoSyntax bool
+	// Prefixed to the failure message.
+	Message string
+	// Print the source text of the actual and expected arguments.
+	Expressions bool
+	// Values of variables of the conditions of True, False and Condition.
+	Vars map[string]interface{}
+	// Prefixes of functions of goroutines which aren't leaks.
+	IgnoredGoroutines []string
+	// Failures are passed to Collect instead of being logged.
+	Collect func(Failure)
+}
+
+type Option func(*Config)
+
+func NewConfig(opts []Option) *Config {
+	c := &Config{}
+	if width, ok := sideBySideWidth(); ok {
+		c.DiffOptions = append(c.DiffOptions, diff.SideBySide(width))
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func DiffOption(opt diff.Option) Option {
+	return func(c *Config) {
+		c.DiffOptions = append(c.DiffOptions, opt)
+	}
+}
+
+func sideBySideWidth() (int, bool) {
+	v := os.Getenv(SideBySideEnvKey)
+	if width, err := strconv.Atoi(v); err == nil {
+		return width, width > 0
+	}
+	on, _ := strconv.ParseBool(v)
+	return 0, on
+}
+
+func Vars(namesAndValues ...interface{}) Option {
+	return func(c *Config) {
+		if c.Vars == nil {
+			c.Vars = map[string]interface{}{}
+		}
+		for i := 0; i+1 < len(namesAndValues); i += 2 {
+			c.Vars[fmt.Sprint(namesAndValues[i])] = namesAndValues[i+1]
+		}
+	}
+}
+
+func Collect(collect func(Failure)) Option {
+	return func(c *Config) {
+		c.Collect = collect
+	}
+}
@@ -0,0 +1,200 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// sprintOrdered formats a value of an ordered kind.
+func sprintOrdered(i interface{}) string {
+	if tm, ok := i.(time.Time); ok {
+		return tm.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf("%#v", i)
+}
+
+func compare(t *testing.T, actual, expected interface{}, ok func(cmp int) bool, relation string, opts []Option) bool {
+	t.Helper()
+
+	cmp, comparable := Compare(actual, expected)
+	if !comparable {
+		Fail(t, opts, fmt.Sprintf("Can't compare %#v and %#v", actual, expected), nil)
+		return false
+	}
+
+	if ok(cmp) {
+		return true
+	}
+
+	Fail(t, opts, fmt.Sprintf("Expected %s %s but got: %s", relation, sprintOrdered(expected), sprintOrdered(actual)), nil)
+	return false
+}
+
+func Greater(t *testing.T, actual, expected interface{}, opts ...Option) bool {
+	t.Helper()
+
+	return compare(t, actual, expected, func(cmp int) bool { return cmp > 0 }, "greater than", opts)
+}
+
+func GreaterOrEqual(t *testing.T, actual, expected interface{}, opts ...Option) bool {
+	t.Helper()
+
+	return compare(t, actual, expected, func(cmp int) bool { return cmp >= 0 }, "greater than or equal to", opts)
+}
+
+func Less(t *testing.T, actual, expected interface{}, opts ...Option) bool {
+	t.Helper()
+
+	return compare(t, actual, expected, func(cmp int) bool { return cmp < 0 }, "less than", opts)
+}
+
+func LessOrEqual(t *testing.T, actual, expected interface{}, opts ...Option) bool {
+	t.Helper()
+
+	return compare(t, actual, expected, func(cmp int) bool { return cmp <= 0 }, "less than or equal to", opts)
+}
+
+func Between(t *testing.T, actual, min, max interface{}, opts ...Option) bool {
+	t.Helper()
+
+	cmpMin, okMin := Compare(actual, min)
+	cmpMax, okMax := Compare(actual, max)
+	if !okMin || !okMax {
+		Fail(t, opts, fmt.Sprintf("Can't compare %#v with %#v and %#v", actual, min, max), nil)
+		return false
+	}
+
+	if cmpMin >= 0 && cmpMax <= 0 {
+		return true
+	}
+
+	Fail(t, opts, fmt.Sprintf("Expected between %s and %s but got: %s",
+		sprintOrdered(min), sprintOrdered(max), sprintOrdered(actual)), nil)
+	return false
+}
+
+// Number of elements shown before and after an out-of-order pair.
+const sortContext = 2
+
+func sprintElem(v reflect.Value) string {
+	i := v.Interface()
+	if _, ok := i.(time.Time); ok || v.Kind() == reflect.String {
+		return fmt.Sprintf("%s(%s)", v.Type(), sprintOrdered(i))
+	}
+
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Ptr, reflect.Slice, reflect.Struct:
+		return fmt.Sprintf("%#v", i)
+	}
+	return fmt.Sprintf("%s(%v)", v.Type(), i)
+}
+
+// sprintOutOfOrder renders the elements around the out-of-order pair i-1
+// and i, the pair is marked by ">".
+func sprintOutOfOrder(v reflect.Value, i int) string {
+	buffer := bytes.NewBuffer(nil)
+	buffer.WriteString(fmt.Sprintf("  %s{\n", v.Type()))
+
+	from := i - 1 - sortContext
+	if from < 0 {
+		from = 0
+	}
+	to := i + sortContext
+	if to > v.Len()-1 {
+		to = v.Len() - 1
+	}
+
+	if from > 0 {
+		buffer.WriteString("      ...\n")
+	}
+	for j := from; j <= to; j++ {
+		prefix := " "
+		if j == i-1 || j == i {
+			prefix = ">"
+		}
+		buffer.WriteString(fmt.Sprintf("%s     %s: %s\n", prefix, strconv.Itoa(j), sprintElem(v.Index(j))))
+	}
+	if to < v.Len()-1 {
+		buffer.WriteString("      ...\n")
+	}
+
+	buffer.WriteString("  }\n")
+	return buffer.String()
+}
+
+func sliceValue(t *testing.T, slice interface{}, opts []Option) (reflect.Value, bool) {
+	t.Helper()
+
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		Fail(t, opts, fmt.Sprintf("Expected slice or array but got: %#v", slice), nil)
+		return v, false
+	}
+	return v, true
+}
+
+// checkOrder finds the first i for which element i-1 and i are out of
+// order.
+func checkOrder(t *testing.T, slice interface{}, inOrder func(v reflect.Value, i int) (bool, error), desc string, opts []Option) bool {
+	t.Helper()
+
+	v, ok := sliceValue(t, slice, opts)
+	if !ok {
+		return false
+	}
+
+	for i := 1; i < v.Len(); i++ {
+		ok, err := inOrder(v, i)
+		if err != nil {
+			Fail(t, opts, err.Error(), nil)
+			return false
+		}
+
+		if !ok {
+			Fail(t, opts, fmt.Sprintf("Expected %s, elements %v and %v are out of order:\n", desc, i-1, i)+
+				sprintOutOfOrder(v, i), nil)
+			return false
+		}
+	}
+	return true
+}
+
+func compareElems(v reflect.Value, i int) (int, error) {
+	x := v.Index(i - 1).Interface()
+	y := v.Index(i).Interface()
+	cmp, ok := Compare(x, y)
+	if !ok {
+		return 0, fmt.Errorf("Can't compare elements %v and %v: %#v and %#v", i-1, i, x, y)
+	}
+	return cmp, nil
+}
+
+func IsSorted(t *testing.T, slice interface{}, opts ...Option) bool {
+	t.Helper()
+
+	return checkOrder(t, slice, func(v reflect.Value, i int) (bool, error) {
+		cmp, err := compareElems(v, i)
+		return cmp <= 0, err
+	}, "sorted", opts)
+}
+
+func IsStrictlyIncreasing(t *testing.T, slice interface{}, opts ...Option) bool {
+	t.Helper()
+
+	return checkOrder(t, slice, func(v reflect.Value, i int) (bool, error) {
+		cmp, err := compareElems(v, i)
+		return cmp < 0, err
+	}, "strictly increasing", opts)
+}
+
+func IsSortedBy(t *testing.T, slice interface{}, less func(i, j int) bool, opts ...Option) bool {
+	t.Helper()
+
+	return checkOrder(t, slice, func(v reflect.Value, i int) (bool, error) {
+		return !less(i, i-1), nil
+	}, "sorted", opts)
+}
@@ -0,0 +1,31 @@
+package internal
+
+import (
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+)
+
+// TestPackage returns the path of the package tested by the test binary,
+// empty if it isn't known.
+func TestPackage() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || !strings.HasSuffix(info.Path, ".test") {
+		return ""
+	}
+	return strings.TrimSuffix(info.Path, ".test")
+}
+
+// PackageFile returns path with the package path of the test binary before
+// the extension, e.g. "report.github.com_go-repo_assert.html" of
+// "report.html", so the test binaries of "go test ./..." write to their own
+// files instead of overwriting each other's.
+func PackageFile(path string) string {
+	pkg := TestPackage()
+	if pkg == "" {
+		return path
+	}
+
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + strings.ReplaceAll(pkg, "/", "_") + ext
+}
@@ -0,0 +1,175 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Indexes of the expected argument of Snapshot(t, actual, expected) and of
+// the method a.Snapshot(actual, expected) of Assertions.
+const (
+	snapshotExpectedArg       = 2
+	snapshotMethodExpectedArg = 1
+)
+
+type lineShift struct {
+	line  int
+	delta int
+}
+
+var (
+	rewriteMu sync.Mutex
+	// Line shifts of rewritten files by original line, lines of callers
+	// are original lines of the compiled source.
+	lineShifts = map[string][]lineShift{}
+)
+
+// currentLine maps an original line of a file to the line after rewrites.
+func currentLine(file string, line int) int {
+	current := line
+	for _, s := range lineShifts[file] {
+		if s.line < line {
+			current += s.delta
+		}
+	}
+	return current
+}
+
+func isSnapshotCall(call *ast.CallExpr) bool {
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		return fun.Sel.Name == "Snapshot"
+	case *ast.Ident:
+		return fun.Name == "Snapshot"
+	}
+	return false
+}
+
+func findSnapshotCall(fset *token.FileSet, f *ast.File, line int) *ast.CallExpr {
+	var call *ast.CallExpr
+	ast.Inspect(f, func(n ast.Node) bool {
+		if call != nil {
+			return false
+		}
+
+		c, ok := n.(*ast.CallExpr)
+		if ok && isSnapshotCall(c) &&
+			fset.Position(c.Pos()).Line <= line && line <= fset.Position(c.Lparen).Line {
+			call = c
+		}
+		return true
+	})
+	return call
+}
+
+// importNames returns the names of the packages imported by a file.
+func importNames(f *ast.File) map[string]bool {
+	names := map[string]bool{}
+	for _, spec := range f.Imports {
+		if spec.Name != nil {
+			names[spec.Name.Name] = true
+			continue
+		}
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err == nil {
+			names[path[strings.LastIndexByte(path, '/')+1:]] = true
+		}
+	}
+	return names
+}
+
+// expectedArg returns the index of the expected argument of a Snapshot
+// call, which is a method call if it's not called on a package.
+func expectedArg(f *ast.File, call *ast.CallExpr) int {
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		if x, ok := sel.X.(*ast.Ident); !ok || !importNames(f)[x.Name] {
+			return snapshotMethodExpectedArg
+		}
+	}
+	return snapshotExpectedArg
+}
+
+func canBeRawString(s string) bool {
+	if !utf8.ValidString(s) || strings.ContainsAny(s, "`\r\ufeff") {
+		return false
+	}
+
+	for _, r := range s {
+		if r < ' ' && r != '\n' && r != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+func stringLiteral(s string) string {
+	if canBeRawString(s) {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+// rewriteSnapshot replaces the expected argument of the Snapshot call at the
+// line of file with a string literal of value, the rest of the file is kept
+// as is.
+func rewriteSnapshot(file string, line int, value string) error {
+	rewriteMu.Lock()
+	defer rewriteMu.Unlock()
+
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	call := findSnapshotCall(fset, f, currentLine(file, line))
+	if call == nil {
+		return fmt.Errorf("Snapshot call not found at %s:%d", file, line)
+	}
+	argIndex := expectedArg(f, call)
+	if len(call.Args) <= argIndex {
+		return fmt.Errorf("expected argument not found at %s:%d", file, line)
+	}
+
+	arg, ok := call.Args[argIndex].(*ast.BasicLit)
+	if !ok || arg.Kind != token.STRING {
+		return fmt.Errorf("expected argument at %s:%d must be a string literal", file, line)
+	}
+
+	start := fset.Position(arg.Pos()).Offset
+	end := fset.Position(arg.End()).Offset
+	lit := stringLiteral(value)
+
+	buffer := bytes.NewBuffer(nil)
+	buffer.Write(src[:start])
+	buffer.WriteString(lit)
+	buffer.Write(src[end:])
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(file, buffer.Bytes(), info.Mode())
+	if err != nil {
+		return err
+	}
+
+	lineShifts[file] = append(lineShifts[file], lineShift{
+		line:  line,
+		delta: strings.Count(lit, "\n") - strings.Count(arg.Value, "\n"),
+	})
+	return nil
+}
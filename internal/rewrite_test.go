@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"go/format"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const rewriteSrc = `package a_test
+
+import (
+	"testing"
+
+	"github.com/go-repo/assert"
+	ea "github.com/go-repo/assert/errorassert"
+)
+
+func TestA(t *testing.T) {
+	assert.Snapshot(t, 1, "")
+	// comment
+	assert.Snapshot(t,
+		"a` + "`" + `b", "old")
+	ea.Snapshot(t, 2, ` + "`old`" + `)
+	assert.New(t).Snapshot(3, "", assert.Msg("c"))
+}
+`
+
+const rewrittenSrc = `package a_test
+
+import (
+	"testing"
+
+	"github.com/go-repo/assert"
+	ea "github.com/go-repo/assert/errorassert"
+)
+
+func TestA(t *testing.T) {
+	assert.Snapshot(t, 1, ` + "`" + `  int(1)
+` + "`" + `)
+	// comment
+	assert.Snapshot(t,
+		"a` + "`" + `b", "a` + "`" + `b")
+	ea.Snapshot(t, 2, ` + "`" + `  int(2)
+` + "`" + `)
+	assert.New(t).Snapshot(3, ` + "`" + `  int(3)
+` + "`" + `, assert.Msg("c"))
+}
+`
+
+func TestRewriteSnapshot(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "a_test.go")
+	err := os.WriteFile(file, []byte(rewriteSrc), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Lines are of the original source, even after previous rewrites.
+	for _, c := range []struct {
+		line  int
+		value string
+	}{
+		{11, string(SerializeSnapshot(1))},
+		{13, "a`b"},
+		{15, string(SerializeSnapshot(2))},
+		{16, string(SerializeSnapshot(3))},
+	} {
+		err = rewriteSnapshot(file, c.line, c.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	src, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(src) != rewrittenSrc {
+		t.Fatal(string(src))
+	}
+
+	formatted, err := format.Source(src)
+	if err != nil || string(formatted) != string(src) {
+		t.Fatal("rewritten source is not gofmt-clean", err)
+	}
+
+	err = rewriteSnapshot(file, 10, "")
+	if err == nil {
+		t.Fatal()
+	}
+}
+
+func TestFuncPackage(t *testing.T) {
+	for fn, pkg := range map[string]string{
+		"github.com/go-repo/assert/internal.Caller":  "github.com/go-repo/assert/internal",
+		"github.com/go-repo/assert.Equal":            "github.com/go-repo/assert",
+		"github.com/go-repo/assert_test.TestA.func1": "github.com/go-repo/assert_test",
+		"main.main":                                       "main",
+		"github.com/go-repo/assert.As[...]":               "github.com/go-repo/assert",
+		"github.com/go-repo/assert/golden_test.TestEqual": "github.com/go-repo/assert/golden_test",
+		"example.com/x.(*T).m":                            "example.com/x",
+	} {
+		if FuncPackage(fn) != pkg {
+			t.Fatal(fn)
+		}
+	}
+
+	if !isLibraryPackage("github.com/go-repo/assert/golden") ||
+		isLibraryPackage("github.com/go-repo/assert/golden_test") ||
+		isLibraryPackage("github.com/go-repo/assert_test") {
+		t.Fatal()
+	}
+}
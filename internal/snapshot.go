@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-repo/assert/diff"
+)
+
+const UpdateEnvKey = "UPDATE_SNAPSHOTS"
+
+// UpdateFlag is the flag registered by the golden package, it's namespaced
+// so it doesn't clash with an -update flag of a test package.
+const UpdateFlag = "golden.update"
+
+// Update reports whether snapshots should be updated, by the UPDATE_SNAPSHOTS
+// environment variable, the -golden.update flag of the golden package or an
+// -update flag registered by the test package.
+func Update() bool {
+	if os.Getenv(UpdateEnvKey) != "" {
+		return true
+	}
+
+	for _, name := range []string{UpdateFlag, "update"} {
+		f := flag.Lookup(name)
+		if f == nil {
+			continue
+		}
+		if update, _ := strconv.ParseBool(f.Value.String()); update {
+			return true
+		}
+	}
+	return false
+}
+
+// SerializeSnapshot returns raw bytes and strings as is, other values are
+// formatted by diff.Sprint.
+func SerializeSnapshot(actual interface{}) []byte {
+	switch v := actual.(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	}
+	return []byte(diff.Sprint(actual))
+}
+
+// LineDiff diffs two texts line by line.
+func LineDiff(actual, expected []byte) string {
+	return diff.Diff(
+		strings.Split(string(actual), "\n"),
+		strings.Split(string(expected), "\n"),
+	)
+}
+
+func Snapshot(t *testing.T, actual interface{}, expected string, opts ...Option) bool {
+	t.Helper()
+
+	data := SerializeSnapshot(actual)
+	if string(data) == expected {
+		return true
+	}
+
+	if Update() {
+		frame, ok := Caller()
+		if !ok {
+			Fail(t, opts, "Can't update snapshot: caller not found", nil)
+			return false
+		}
+
+		err := rewriteSnapshot(frame.File, frame.Line, string(data))
+		if err != nil {
+			Fail(t, opts, fmt.Sprintf("Can't update snapshot: %v", err), nil)
+			return false
+		}
+
+		t.Logf("Updated snapshot at %s:%d\n", frame.File, frame.Line)
+		return true
+	}
+
+	Fail(t, opts, "Actual (-) and snapshot (+) are not equal:\n"+LineDiff(data, []byte(expected)), nil)
+	return false
+}
@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-repo/assert/diff"
+)
+
+func WithinDuration(t *testing.T, actual, expected time.Time, delta time.Duration, opts ...Option) bool {
+	t.Helper()
+
+	diffOpts := append(NewConfig(opts).DiffOptions, diff.TimeTolerance(delta))
+	tree, ok := equal(actual, expected, diffOpts)
+	if ok {
+		return true
+	}
+
+	Fail(t, opts, fmt.Sprintf("Actual (-) and expected (+) are not within %v:\n", delta)+
+		diff.DiffTree(tree, diffOpts...), tree)
+	return false
+}
+
+func TimeEqual(t *testing.T, actual, expected time.Time, opts ...Option) bool {
+	t.Helper()
+
+	diffOpts := append(NewConfig(opts).DiffOptions, diff.TimeTolerance(0))
+	tree, ok := equal(actual, expected, diffOpts)
+	if ok {
+		return true
+	}
+
+	Fail(t, opts, "Actual (-) and expected (+) are not the same instant:\n"+diff.DiffTree(tree, diffOpts...), tree)
+	return false
+}
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func typeString(typ reflect.Type) string {
+	if typ == nil {
+		return "nil"
+	}
+	return typ.String()
+}
+
+func IsType(t *testing.T, expectedType, actual interface{}, opts ...Option) bool {
+	t.Helper()
+
+	if reflect.TypeOf(actual) == reflect.TypeOf(expectedType) {
+		return true
+	}
+
+	Fail(t, opts, fmt.Sprintf("Expected type %s but got %s: %#v",
+		typeString(reflect.TypeOf(expectedType)), typeString(reflect.TypeOf(actual)), actual), nil)
+	return false
+}
+
+func Implements(t *testing.T, interfaceObject, actual interface{}, opts ...Option) bool {
+	t.Helper()
+
+	typ := reflect.TypeOf(interfaceObject)
+	if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Interface {
+		Fail(t, opts, fmt.Sprintf("Expected a pointer to an interface, e.g. (*io.Reader)(nil), but got: %#v", interfaceObject), nil)
+		return false
+	}
+
+	iface := typ.Elem()
+	actualType := reflect.TypeOf(actual)
+	if actualType != nil && actualType.Implements(iface) {
+		return true
+	}
+
+	Fail(t, opts, fmt.Sprintf("Expected implementation of %s but got %s: %#v", iface, typeString(actualType), actual), nil)
+	return false
+}
+
+// targetType returns the type of expectedType or, like Implements, the
+// interface of a pointer to an interface, e.g. error of (*error)(nil).
+func targetType(expectedType interface{}) reflect.Type {
+	typ := reflect.TypeOf(expectedType)
+	if typ != nil && typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Interface {
+		return typ.Elem()
+	}
+	return typ
+}
+
+func AssignableTo(t *testing.T, expectedType, actual interface{}, opts ...Option) bool {
+	t.Helper()
+
+	typ := targetType(expectedType)
+	actualType := reflect.TypeOf(actual)
+	if typ != nil && actualType != nil && actualType.AssignableTo(typ) {
+		return true
+	}
+
+	Fail(t, opts, fmt.Sprintf("Expected value assignable to %s but got %s: %#v", typeString(typ), typeString(actualType), actual), nil)
+	return false
+}
+
+func ConvertibleTo(t *testing.T, expectedType, actual interface{}, opts ...Option) bool {
+	t.Helper()
+
+	typ := targetType(expectedType)
+	actualType := reflect.TypeOf(actual)
+	if typ != nil && actualType != nil && actualType.ConvertibleTo(typ) {
+		return true
+	}
+
+	Fail(t, opts, fmt.Sprintf("Expected value convertible to %s but got %s: %#v", typeString(typ), typeString(actualType), actual), nil)
+	return false
+}
+
+func As[T any](t *testing.T, actual interface{}, opts ...Option) (T, bool) {
+	t.Helper()
+
+	v, ok := actual.(T)
+	if ok {
+		return v, true
+	}
+
+	Fail(t, opts, fmt.Sprintf("Expected type %s but got %s: %#v",
+		reflect.TypeOf((*T)(nil)).Elem(), typeString(reflect.TypeOf(actual)), actual), nil)
+	return v, false
+}
@@ -0,0 +1,185 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-repo/assert/diff"
+	"github.com/go-repo/assert/internal"
+)
+
+// Matcher can be placed anywhere in the expected value of Equal, including
+// struct fields of interface type, map values and slice elements, to match
+// the actual value by a rule instead of by equality.
+type Matcher = diff.Matcher
+
+type matcher struct {
+	desc  string
+	match func(actual interface{}) error
+}
+
+func (m *matcher) Match(actual interface{}) error {
+	return m.match(actual)
+}
+
+func (m *matcher) String() string {
+	return m.desc
+}
+
+func sprintType(actual interface{}) string {
+	if actual == nil {
+		return "nil"
+	}
+	return reflect.TypeOf(actual).String()
+}
+
+// Any matches any value, including nil.
+func Any() Matcher {
+	return &matcher{
+		desc: "Any()",
+		match: func(interface{}) error {
+			return nil
+		},
+	}
+}
+
+// AnyString matches any value of string kind.
+func AnyString() Matcher {
+	return &matcher{
+		desc: "AnyString()",
+		match: func(actual interface{}) error {
+			if actual == nil || reflect.ValueOf(actual).Kind() != reflect.String {
+				return fmt.Errorf("got %s", sprintType(actual))
+			}
+			return nil
+		},
+	}
+}
+
+// AnyTime matches any time.Time or non-nil *time.Time.
+func AnyTime() Matcher {
+	return &matcher{
+		desc: "AnyTime()",
+		match: func(actual interface{}) error {
+			switch a := actual.(type) {
+			case time.Time:
+				return nil
+			case *time.Time:
+				if a != nil {
+					return nil
+				}
+			}
+			return fmt.Errorf("got %s", sprintType(actual))
+		},
+	}
+}
+
+// AnyOf matches a value which is equal to one of values.
+func AnyOf(values ...interface{}) Matcher {
+	var descs []string
+	for _, v := range values {
+		descs = append(descs, fmt.Sprintf("%#v", v))
+	}
+
+	return &matcher{
+		desc: fmt.Sprintf("AnyOf(%s)", strings.Join(descs, ", ")),
+		match: func(actual interface{}) error {
+			for _, v := range values {
+				if reflect.DeepEqual(actual, v) || diff.Diff(actual, v) == "" {
+					return nil
+				}
+			}
+			return fmt.Errorf("no value is equal")
+		},
+	}
+}
+
+// NotZero matches a value which is not nil and not the zero value of its
+// type.
+func NotZero() Matcher {
+	return &matcher{
+		desc: "NotZero()",
+		match: func(actual interface{}) error {
+			if actual == nil || reflect.ValueOf(actual).IsZero() {
+				return fmt.Errorf("got zero value")
+			}
+			return nil
+		},
+	}
+}
+
+// Regexp matches a string, []byte or fmt.Stringer containing a match of the
+// regular expression pattern. It panics if pattern can't be compiled.
+func Regexp(pattern string) Matcher {
+	re := regexp.MustCompile(pattern)
+
+	return &matcher{
+		desc: fmt.Sprintf("Regexp(%q)", pattern),
+		match: func(actual interface{}) error {
+			var s string
+			switch a := actual.(type) {
+			case string:
+				s = a
+			case []byte:
+				s = string(a)
+			case fmt.Stringer:
+				s = a.String()
+			default:
+				if actual == nil || reflect.ValueOf(actual).Kind() != reflect.String {
+					return fmt.Errorf("got %s", sprintType(actual))
+				}
+				s = reflect.ValueOf(actual).String()
+			}
+
+			if !re.MatchString(s) {
+				return fmt.Errorf("no match")
+			}
+			return nil
+		},
+	}
+}
+
+// InRange matches a number or string which is between min and max
+// inclusive.
+func InRange(min, max interface{}) Matcher {
+	return &matcher{
+		desc: fmt.Sprintf("InRange(%#v, %#v)", min, max),
+		match: func(actual interface{}) error {
+			cmpMin, okMin := internal.Compare(actual, min)
+			cmpMax, okMax := internal.Compare(actual, max)
+			if !okMin || !okMax {
+				return fmt.Errorf("can't compare %s", sprintType(actual))
+			}
+
+			if cmpMin < 0 || cmpMax > 0 {
+				return fmt.Errorf("out of range")
+			}
+			return nil
+		},
+	}
+}
+
+// Approx matches a number which differs from expected by at most delta.
+func Approx(expected, delta float64) Matcher {
+	return &matcher{
+		desc: fmt.Sprintf("Approx(%v, %v)", expected, delta),
+		match: func(actual interface{}) error {
+			f, ok := internal.ToFloat(actual)
+			if !ok {
+				return fmt.Errorf("got %s", sprintType(actual))
+			}
+
+			d := f - expected
+			if d < 0 {
+				d = -d
+			}
+			if !(d <= delta) {
+				return fmt.Errorf("differs by %v", d)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package assert
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-repo/assert/diff"
+	"github.com/go-repo/assert/internal"
+)
+
+// Option changes how an assertion compares values, options can also be
+// passed to the assertions of errorassert.
+type Option = internal.Option
+
+// FloatDelta makes floats and complex numbers in nested values equal if
+// their absolute difference is at most delta.
+func FloatDelta(delta float64) Option {
+	return internal.DiffOption(diff.FloatDelta(delta))
+}
+
+// FloatEpsilon makes floats and complex numbers in nested values equal if
+// their difference relative to the larger absolute value is at most epsilon.
+func FloatEpsilon(epsilon float64) Option {
+	return internal.DiffOption(diff.FloatEpsilon(epsilon))
+}
+
+// FloatULP makes floats and complex numbers in nested values equal if there
+// are at most ulps representable floats between them.
+func FloatULP(ulps uint64) Option {
+	return internal.DiffOption(diff.FloatULP(ulps))
+}
+
+// EquateNaNs makes NaN equal to NaN.
+func EquateNaNs() Option {
+	return internal.DiffOption(diff.EquateNaNs())
+}
+
+// TimeTolerance makes times in nested values equal if they differ by at
+// most tolerance, regardless of location and monotonic clock reading.
+func TimeTolerance(tolerance time.Duration) Option {
+	return internal.DiffOption(diff.TimeTolerance(tolerance))
+}
+
+// GoSyntax appends the actual value as a Go literal to the failure message
+// of Equal, so it can be copied into the test as the expected value.
+func GoSyntax() Option {
+	return func(c *internal.Config) {
+		c.GoSyntax = true
+	}
+}
+
+// Expressions prints the source text of the actual and expected arguments
+// above the diff of a failed assertion, e.g. "actual:   resp.Items[0].Price".
+func Expressions() Option {
+	return func(c *internal.Config) {
+		c.Expressions = true
+	}
+}
+
+// SideBySide prints diffs in two columns of the total width, actual on the
+// left and expected on the right. It can be set for all assertions by
+// ASSERT_SIDE_BY_SIDE=<width> or ASSERT_SIDE_BY_SIDE=1 for the default width.
+func SideBySide(width int) Option {
+	return internal.DiffOption(diff.SideBySide(width))
+}
+
+// Msg prefixes the failure message of an assertion with a formatted
+// message, e.g. to tell which iteration of a loop failed.
+func Msg(format string, args ...interface{}) Option {
+	return func(c *internal.Config) {
+		c.Message = fmt.Sprintf(format, args...)
+	}
+}
+
+// Vars passes the values of variables of the condition of True, False and
+// Condition by name and value pairs, the failure message shows the values of
+// the sub-expressions which can be evaluated from them, e.g. "len(users)".
+func Vars(namesAndValues ...interface{}) Option {
+	return internal.Vars(namesAndValues...)
+}
+
+// IgnoreGoroutines makes NoGoroutineLeaks ignore goroutines with a function
+// in their stack which starts with one of the prefixes, e.g.
+// "net/http.(*persistConn)" or "go.opencensus.io/stats/view.(*worker).start".
+func IgnoreGoroutines(prefixes ...string) Option {
+	return func(c *internal.Config) {
+		c.IgnoredGoroutines = append(c.IgnoredGoroutines, prefixes...)
+	}
+}
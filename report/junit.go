@@ -0,0 +1,90 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr"`
+	File      string       `xml:"file,attr,omitempty"`
+	Line      int          `xml:"line,attr,omitempty"`
+	Failure   junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// failureText returns the failed assertions of a test with their locations
+// and messages.
+func failureText(t test) string {
+	b := &strings.Builder{}
+	for i, f := range t.failures {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(b, "%s:%d: %s\n%s\n", f.File, f.Line, f.Assertion, f.Message)
+	}
+	return b.String()
+}
+
+// writeJUnit writes a test case for each test with failed assertions, the
+// first one is the message of the failure. Passing tests aren't known, so
+// the suite has no tests count.
+func writeJUnit(w io.Writer, suite string, tests []test) error {
+	s := junitTestSuite{
+		Name:     suite,
+		Failures: len(tests),
+	}
+	for _, t := range tests {
+		first := t.failures[0]
+		title, _, _ := strings.Cut(first.Message, "\n")
+		if len(t.failures) > 1 {
+			title = fmt.Sprintf("%s (and %d more failed assertions)", title, len(t.failures)-1)
+		}
+
+		s.TestCases = append(s.TestCases, junitTestCase{
+			Name:      t.name,
+			ClassName: suite,
+			File:      first.File,
+			Line:      first.Line,
+			Failure: junitFailure{
+				Message: title,
+				Type:    first.Assertion,
+				Text:    failureText(t),
+			},
+		})
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	err = e.Encode(junitTestSuites{Suites: []junitTestSuite{s}})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,162 @@
+// Package report writes the failed assertions of a test binary as a JUnit
+// XML or TAP report when the tests are run via Run:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(report.Run(m))
+//	}
+//
+// The report is selected by options of Run or by the environment variable
+// ASSERT_REPORT, e.g. ASSERT_REPORT=junit:report.xml or ASSERT_REPORT=tap.
+// A relative path is relative to the package directory of the tests. Each
+// test binary writes its own report with the package path before the
+// extension, e.g. report.github.com_go-repo_assert.xml, so the reports of
+// "go test ./..." don't overwrite each other.
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-repo/assert/internal"
+)
+
+const EnvKey = "ASSERT_REPORT"
+
+// Default paths of the reports.
+const (
+	JUnitFile = "report.xml"
+	TAPFile   = "report.tap"
+)
+
+type config struct {
+	write func(w io.Writer, suite string, tests []test) error
+	path  string
+}
+
+// Option selects the report written by Run.
+type Option func(*config)
+
+// JUnit writes a JUnit XML report to path.
+func JUnit(path string) Option {
+	return func(c *config) {
+		c.write = writeJUnit
+		c.path = path
+	}
+}
+
+// TAP writes a TAP version 13 report to path.
+func TAP(path string) Option {
+	return func(c *config) {
+		c.write = writeTAP
+		c.path = path
+	}
+}
+
+func envOption() (Option, error) {
+	format, path, _ := strings.Cut(os.Getenv(EnvKey), ":")
+	switch format {
+	case "":
+		return nil, nil
+	case "junit":
+		if path == "" {
+			path = JUnitFile
+		}
+		return JUnit(path), nil
+	case "tap":
+		if path == "" {
+			path = TAPFile
+		}
+		return TAP(path), nil
+	}
+	return nil, fmt.Errorf("unknown %s %q", EnvKey, format)
+}
+
+// test with its failed assertions.
+type test struct {
+	name     string
+	failures []internal.Failure
+}
+
+type recorder struct {
+	sync.Mutex
+	tests []test
+	index map[string]int
+}
+
+func (r *recorder) record(f internal.Failure) {
+	r.Lock()
+	defer r.Unlock()
+
+	i, ok := r.index[f.Test]
+	if !ok {
+		i = len(r.tests)
+		r.index[f.Test] = i
+		r.tests = append(r.tests, test{name: f.Test})
+	}
+	r.tests[i].failures = append(r.tests[i].failures, f)
+}
+
+func write(path string, c *config, suite string, tests []test) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = c.write(f, suite, tests)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Run runs the tests and writes a report of the tests with failed
+// assertions, named by the package of the caller.
+func Run(m *testing.M, opts ...Option) int {
+	suite := ""
+	if pc, _, _, ok := runtime.Caller(1); ok {
+		suite = strings.TrimSuffix(internal.FuncPackage(runtime.FuncForPC(pc).Name()), "_test")
+	}
+
+	if len(opts) == 0 {
+		opt, err := envOption()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "report: %v\n", err)
+			return 1
+		}
+		if opt == nil {
+			return m.Run()
+		}
+		opts = []Option{opt}
+	}
+
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	r := &recorder{index: map[string]int{}}
+	internal.OnFailure(r.record)
+	code := m.Run()
+
+	r.Lock()
+	defer r.Unlock()
+
+	path := internal.PackageFile(c.path)
+	err := write(path, c, suite, r.tests)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "report: can't write %s: %v\n", path, err)
+		return 1
+	}
+	return code
+}
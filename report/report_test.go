@@ -0,0 +1,119 @@
+package report_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/go-repo/assert/errorassert"
+	"github.com/go-repo/assert/report"
+)
+
+const emitEnvKey = "TEST_EMIT_FAILURES"
+
+func TestMain(m *testing.M) {
+	os.Exit(report.Run(m))
+}
+
+// TestEmit fails on purpose when it's run by the tests of the reports, it
+// prints the lines of its assertions first.
+func TestEmit(t *testing.T) {
+	if os.Getenv(emitEnvKey) == "" {
+		return
+	}
+
+	_, _, line, _ := runtime.Caller(0)
+	fmt.Printf("%v:%v:%v\n", line+2, line+4, line+5)
+	errorassert.Equal(t, 1, 2)
+	t.Run("sub", func(t *testing.T) {
+		errorassert.NoError(t, os.ErrNotExist)
+		errorassert.Nil(t, "<a & b>")
+	})
+}
+
+// runEmit runs TestEmit with ASSERT_REPORT=format:path and returns the
+// report, the file of TestEmit and the lines of its assertions.
+func runEmit(t *testing.T, format string) (string, string, []string) {
+	path := filepath.Join(t.TempDir(), "report")
+	cmd := exec.Command("go", "test", "-count=1", "-run", "^TestEmit$")
+	for _, env := range os.Environ() {
+		if !strings.HasPrefix(env, "ASSERT_") {
+			cmd.Env = append(cmd.Env, env)
+		}
+	}
+	cmd.Env = append(cmd.Env, emitEnvKey+"=1", report.EnvKey+"="+format+":"+path)
+
+	output, err := cmd.CombinedOutput()
+	if _, ok := err.(*exec.ExitError); !ok {
+		t.Fatalf("expected failed tests but got: %v\n%s", err, output)
+	}
+
+	data, err := os.ReadFile(path + ".github.com_go-repo_assert_report")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, file, _, _ := runtime.Caller(0)
+	first, _, _ := strings.Cut(string(output), "\n")
+	lines := strings.Split(first, ":")
+	if len(lines) != 3 {
+		t.Fatalf("expected lines of assertions but got:\n%s", output)
+	}
+	return string(data), file, lines
+}
+
+func TestRun__JUnit(t *testing.T) {
+	actual, file, lines := runEmit(t, "junit")
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="github.com/go-repo/assert/report" failures="2">
+    <testcase name="TestEmit" classname="github.com/go-repo/assert/report" file="` + file + `" line="` + lines[0] + `">
+      <failure message="Actual (-) and expected (+) are not equal:" type="errorassert.Equal">` + file + `:` + lines[0] + `: errorassert.Equal&#xA;Actual (-) and expected (+) are not equal:&#xA;- int(1)&#xA;+ int(2)&#xA;</failure>
+    </testcase>
+    <testcase name="TestEmit/sub" classname="github.com/go-repo/assert/report" file="` + file + `" line="` + lines[1] + `">
+      <failure message="Got unexpected error: file does not exist (and 1 more failed assertions)" type="errorassert.NoError">` + file + `:` + lines[1] + `: errorassert.NoError&#xA;Got unexpected error: file does not exist&#xA;&#xA;` + file + `:` + lines[2] + `: errorassert.Nil&#xA;Expected nil but got: &#34;&lt;a &amp; b&gt;&#34;&#xA;</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	if actual != expected {
+		t.Fatalf("expected:\n%s\nbut got:\n%s", expected, actual)
+	}
+}
+
+func TestRun__TAP(t *testing.T) {
+	actual, file, lines := runEmit(t, "tap")
+	expected := `TAP version 13
+# github.com/go-repo/assert/report
+1..2
+not ok 1 - TestEmit
+  ---
+  failures:
+    - assertion: "errorassert.Equal"
+      at: "` + file + `:` + lines[0] + `"
+      message: |
+        Actual (-) and expected (+) are not equal:
+        - int(1)
+        + int(2)
+  ...
+not ok 2 - TestEmit/sub
+  ---
+  failures:
+    - assertion: "errorassert.NoError"
+      at: "` + file + `:` + lines[1] + `"
+      message: |
+        Got unexpected error: file does not exist
+    - assertion: "errorassert.Nil"
+      at: "` + file + `:` + lines[2] + `"
+      message: |
+        Expected nil but got: "<a & b>"
+  ...
+`
+	if actual != expected {
+		t.Fatalf("expected:\n%s\nbut got:\n%s", expected, actual)
+	}
+}
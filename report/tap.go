@@ -0,0 +1,35 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// yamlBlock indents the lines of a YAML block scalar.
+func yamlBlock(s, indent string) string {
+	return indent + strings.ReplaceAll(s, "\n", "\n"+indent)
+}
+
+// writeTAP writes a failed test point for each test with failed assertions,
+// the assertions are listed in the YAML block of the test point.
+func writeTAP(w io.Writer, suite string, tests []test) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "TAP version 13\n# %s\n1..%d\n", suite, len(tests))
+
+	for i, t := range tests {
+		fmt.Fprintf(b, "not ok %d - %s\n", i+1, t.name)
+		b.WriteString("  ---\n  failures:\n")
+		for _, f := range t.failures {
+			fmt.Fprintf(b, "    - assertion: %q\n", f.Assertion)
+			fmt.Fprintf(b, "      at: %q\n", fmt.Sprintf("%s:%d", f.File, f.Line))
+			b.WriteString("      message: |\n")
+			b.WriteString(yamlBlock(f.Message, "        "))
+			b.WriteString("\n")
+		}
+		b.WriteString("  ...\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,99 @@
+package assert
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-repo/assert/internal"
+)
+
+// SoftAssertions collect the failures of their assertions and report them
+// together by Verify, the test goes on after a failure.
+type SoftAssertions struct {
+	*Assertions
+
+	mu       sync.Mutex
+	failures []internal.Failure
+}
+
+// NewSoft returns the soft assertions of t, the failures are reported by
+// Verify or VerifyNow:
+//
+//	s := assert.NewSoft(t)
+//	defer s.Verify()
+func NewSoft(t *testing.T) *SoftAssertions {
+	s := &SoftAssertions{}
+	s.Assertions = &Assertions{t: t, strategy: LogOnly, opts: []Option{internal.Collect(s.collect)}}
+	return s
+}
+
+// Soft calls fn with soft assertions of t and reports their failures at the
+// end of fn, it returns whether all assertions passed.
+func Soft(t *testing.T, fn func(s *SoftAssertions)) (ok bool) {
+	t.Helper()
+
+	s := NewSoft(t)
+	defer func() {
+		t.Helper()
+
+		ok = s.Verify()
+	}()
+
+	fn(s)
+	return
+}
+
+func (s *SoftAssertions) collect(f internal.Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, f)
+}
+
+// Verify logs a numbered summary of the failures collected since the last
+// verification and marks the test as failed if there are any.
+func (s *SoftAssertions) Verify() bool {
+	s.t.Helper()
+
+	s.mu.Lock()
+	failures := s.failures
+	s.failures = nil
+	s.mu.Unlock()
+
+	if len(failures) == 0 {
+		return true
+	}
+
+	s.t.Log(sprintFailures(failures))
+	s.t.Fail()
+	return false
+}
+
+// VerifyNow is like Verify but stops the test if there are failures.
+func (s *SoftAssertions) VerifyNow() {
+	s.t.Helper()
+
+	if !s.Verify() {
+		s.t.FailNow()
+	}
+}
+
+func sprintFailures(failures []internal.Failure) string {
+	var b strings.Builder
+	if len(failures) == 1 {
+		b.WriteString("1 soft assertion failed:")
+	} else {
+		fmt.Fprintf(&b, "%v soft assertions failed:", len(failures))
+	}
+
+	for i, f := range failures {
+		prefix := fmt.Sprintf("%v) ", i+1)
+		indent := strings.Repeat(" ", len(prefix))
+		msg := strings.ReplaceAll(f.Message, "\n", "\n"+indent)
+		fmt.Fprintf(&b, "\n%s%s:%v: %s", prefix, filepath.Base(f.File), f.Line, msg)
+	}
+	return b.String()
+}